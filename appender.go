@@ -0,0 +1,371 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// appenderIDBlock is the most sequence values AppendModel reserves per round
+// trip for auto-increment columns
+const appenderIDBlock = 1024
+
+// AppenderOptions configures an Appender
+type AppenderOptions struct {
+	// Schema of the target table (empty = default schema)
+	Schema string
+
+	// FlushEvery flushes appended rows to the table every N rows so a large
+	// load does not accumulate in memory and becomes visible incrementally.
+	// 0 leaves flushing to DuckDB's internal threshold and Close.
+	FlushEvery int
+}
+
+// Appender streams rows into a table through DuckDB's native appender,
+// bypassing SQL parsing for bulk loads. It holds one pooled connection until
+// Close is called, and is not safe for concurrent use.
+type Appender struct {
+	conn     *sql.Conn
+	appender *duckdb.Appender
+	options  AppenderOptions
+	pending  int
+
+	ctx       context.Context
+	nowFunc   func() time.Time
+	columns   []appenderColumn
+	remaining int // rows of the current AppendModel call left to append
+}
+
+// appenderColumn maps a table column to the model field AppendModel reads
+type appenderColumn struct {
+	field *schema.Field
+
+	// nextval is the column's sequence default, evaluated for rows whose
+	// field is zero since the appender does not apply column defaults
+	nextval  string
+	reserved []int64
+}
+
+// NewAppender opens a native appender on table. Columns are appended in the
+// table's column order. Pass nil options for the defaults.
+//
+// With an empty table the table is resolved from db's model, and AppendModel
+// appends structs of that model:
+//
+//	appender, err := duckdb.NewAppender(db.Model(&Reading{}), "", nil)
+//
+// AppendModel reserves auto-increment keys from the sequence up to the number
+// of rows it is given at a time. Keys reserved for rows that turn out to have
+// one already, or that fail to append, are skipped, leaving gaps in the
+// sequence as a failed INSERT does.
+func NewAppender(db *gorm.DB, table string, options *AppenderOptions) (*Appender, error) {
+	if options == nil {
+		options = &AppenderOptions{}
+	}
+
+	var modelSchema *schema.Schema
+	if table == "" {
+		if db.Statement.Model == nil {
+			return nil, fmt.Errorf("failed to create appender: no table name or model given")
+		}
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(db.Statement.Model); err != nil {
+			return nil, fmt.Errorf("failed to parse model: %w", err)
+		}
+		table, modelSchema = stmt.Table, stmt.Schema
+		if idx := strings.LastIndex(table, "."); idx > 0 && options.Schema == "" {
+			options = &AppenderOptions{Schema: table[:idx], FlushEvery: options.FlushEvery}
+			table = table[idx+1:]
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying database: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(statementContext(db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection for appender: %w", err)
+	}
+
+	a := &Appender{conn: conn, options: *options, ctx: statementContext(db), nowFunc: db.NowFunc}
+	if modelSchema != nil {
+		if a.columns, err = a.modelColumns(modelSchema, options.Schema, table); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to create appender for table %s: %w", table, err)
+		}
+	}
+	err = conn.Raw(func(driverConn interface{}) error {
+		duckConn, err := unwrapDuckDBConn(driverConn)
+		if err != nil {
+			return err
+		}
+		a.appender, err = duckdb.NewAppenderFromConn(duckConn, options.Schema, table)
+		return err
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to create appender for table %s: %w", table, err)
+	}
+
+	return a, nil
+}
+
+// BulkInsert loads records, a slice (or pointer to a slice) of structs of one
+// model, into the model's table through the native Appender, which skips SQL
+// parsing and binding entirely and is the fastest way to load large slices.
+// Fields are matched to columns through the parsed schema and converted as
+// AppendModel does: zero auto-increment keys are filled from the column's
+// sequence and written back, and zero autoCreateTime/autoUpdateTime fields
+// get the current time. Unlike Create it runs no hooks or associations.
+//
+// It returns the number of rows appended. If a record fails to append, the
+// records before it may already be in the table, and the keys reserved for
+// the rest are skipped by the sequence (see NewAppender).
+func BulkInsert(db *gorm.DB, records interface{}) (int64, error) {
+	rv := reflect.Indirect(reflect.ValueOf(records))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, fmt.Errorf("failed to bulk insert: expected a slice of structs, got %T", records)
+	}
+	if rv.Len() == 0 {
+		return 0, nil
+	}
+
+	appender, err := NewAppender(db.Model(records), "", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk insert: %w", err)
+	}
+	if err := appender.AppendModel(records); err != nil {
+		_ = appender.Close()
+		return 0, fmt.Errorf("failed to bulk insert: %w", err)
+	}
+	if err := appender.Close(); err != nil {
+		return 0, fmt.Errorf("failed to bulk insert: %w", err)
+	}
+	return int64(rv.Len()), nil
+}
+
+// AppendRow buffers one row, flushing when FlushEvery rows are pending
+func (a *Appender) AppendRow(values ...interface{}) error {
+	args := make([]driver.Value, len(values))
+	for i, value := range values {
+		converted, err := appenderValue(value)
+		if err != nil {
+			return fmt.Errorf("failed to convert appender value %d: %w", i, err)
+		}
+		args[i] = converted
+	}
+
+	if err := a.withConn(func() error { return a.appender.AppendRow(args...) }); err != nil {
+		return fmt.Errorf("failed to append row: %w", err)
+	}
+
+	a.pending++
+	if a.options.FlushEvery > 0 && a.pending >= a.options.FlushEvery {
+		return a.Flush()
+	}
+	return nil
+}
+
+// AppendModel appends value, a struct or slice of structs of the model the
+// appender was created for, in the table's column order. Zero auto-increment
+// keys are filled from their sequence and zero autoCreateTime/autoUpdateTime
+// fields with the current time, as Create would, and written back to value
+// when it is addressable.
+func (a *Appender) AppendModel(value interface{}) error {
+	if a.columns == nil {
+		return fmt.Errorf("failed to append model: appender was not created for a model")
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			a.remaining = rv.Len() - i
+			if err := a.appendStruct(reflect.Indirect(rv.Index(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		a.remaining = 1
+		return a.appendStruct(rv)
+	default:
+		return fmt.Errorf("failed to append model: unsupported value %T", value)
+	}
+}
+
+func (a *Appender) appendStruct(row reflect.Value) error {
+	if !row.CanAddr() {
+		addressable := reflect.New(row.Type()).Elem()
+		addressable.Set(row)
+		row = addressable
+	}
+
+	values := make([]interface{}, len(a.columns))
+	for i := range a.columns {
+		column := &a.columns[i]
+		if column.field == nil {
+			continue
+		}
+
+		fieldValue, zero := column.field.ValueOf(a.ctx, row)
+		if zero {
+			var fill interface{}
+			switch {
+			case column.nextval != "":
+				id, err := a.nextID(column)
+				if err != nil {
+					return err
+				}
+				fill = id
+			case column.field.DataType == schema.Time && (column.field.AutoCreateTime > 0 || column.field.AutoUpdateTime > 0):
+				fill = a.nowFunc()
+			}
+			if fill != nil {
+				if err := column.field.Set(a.ctx, row, fill); err != nil {
+					return fmt.Errorf("failed to set %s: %w", column.field.Name, err)
+				}
+				fieldValue, _ = column.field.ValueOf(a.ctx, row)
+			}
+		}
+		values[i] = fieldValue
+	}
+	return a.AppendRow(values...)
+}
+
+// nextID returns the next value of an auto-increment column's sequence,
+// reserving one value per row left in the AppendModel call, at most
+// appenderIDBlock at a time
+func (a *Appender) nextID(column *appenderColumn) (int64, error) {
+	if len(column.reserved) == 0 {
+		block := appenderIDBlock
+		if a.remaining > 0 && a.remaining < block {
+			block = a.remaining
+		}
+		rows, err := a.conn.QueryContext(a.ctx, "SELECT "+column.nextval+" FROM range(?)", block)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reserve %s values: %w", column.field.DBName, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return 0, fmt.Errorf("failed to reserve %s values: %w", column.field.DBName, err)
+			}
+			column.reserved = append(column.reserved, id)
+		}
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("failed to reserve %s values: %w", column.field.DBName, err)
+		}
+	}
+
+	id := column.reserved[0]
+	column.reserved = column.reserved[1:]
+	return id, nil
+}
+
+// modelColumns reads the table's columns in order and matches them to the
+// model's fields
+func (a *Appender) modelColumns(modelSchema *schema.Schema, schemaName, table string) ([]appenderColumn, error) {
+	rows, err := a.conn.QueryContext(a.ctx, `SELECT column_name, column_default FROM duckdb_columns()
+		WHERE database_name = current_database() AND schema_name = coalesce(nullif(?, ''), current_schema()) AND table_name = ?
+		ORDER BY column_index`, schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []appenderColumn
+	for rows.Next() {
+		var name string
+		var columnDefault sql.NullString
+		if err := rows.Scan(&name, &columnDefault); err != nil {
+			return nil, fmt.Errorf("failed to read columns: %w", err)
+		}
+		column := appenderColumn{field: modelSchema.LookUpField(name)}
+		if column.field != nil && strings.HasPrefix(columnDefault.String, "nextval(") {
+			column.nextval = columnDefault.String
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return columns, nil
+}
+
+// Flush writes all buffered rows to the table
+func (a *Appender) Flush() error {
+	if err := a.withConn(a.appender.Flush); err != nil {
+		return fmt.Errorf("failed to flush appender: %w", err)
+	}
+	a.pending = 0
+	return nil
+}
+
+// Close flushes any remaining rows, releases the appender and returns its
+// connection to the pool
+func (a *Appender) Close() error {
+	err := a.withConn(a.appender.Close)
+	a.pending = 0
+	if closeErr := a.conn.Close(); err == nil && closeErr != nil {
+		return fmt.Errorf("failed to release appender connection: %w", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close appender: %w", err)
+	}
+	return nil
+}
+
+// withConn runs fn while holding the appender's driver connection, as
+// database/sql only permits using a raw connection inside Conn.Raw
+func (a *Appender) withConn(fn func() error) error {
+	return a.conn.Raw(func(interface{}) error { return fn() })
+}
+
+// unwrapDuckDBConn returns the go-duckdb connection behind a pooled driver
+// connection, which the native appender requires
+func unwrapDuckDBConn(driverConn interface{}) (driver.Conn, error) {
+	switch c := driverConn.(type) {
+	case *convertingConn:
+		return c.Conn, nil
+	case *duckdb.Conn:
+		return c, nil
+	default:
+		return nil, fmt.Errorf("connection %T is not a DuckDB connection", driverConn)
+	}
+}
+
+// appenderValue converts a Go value to one the native appender accepts
+func appenderValue(value interface{}) (driver.Value, error) {
+	switch v := value.(type) {
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case driver.Valuer:
+		return v.Value()
+	default:
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil, nil
+			}
+			return appenderValue(rv.Elem().Interface())
+		}
+		return value, nil
+	}
+}
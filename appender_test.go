@@ -0,0 +1,234 @@
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func setupAppenderTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE readings (id INTEGER, sensor VARCHAR, value DOUBLE, taken_at TIMESTAMP)").Error)
+	return db
+}
+
+func countReadings(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Table("readings").Count(&count).Error)
+	return count
+}
+
+func TestAppender_FlushEvery(t *testing.T) {
+	db := setupAppenderTestDB(t)
+
+	appender, err := duckdb.NewAppender(db, "readings", &duckdb.AppenderOptions{FlushEvery: 100})
+	require.NoError(t, err)
+
+	takenAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 250; i++ {
+		require.NoError(t, appender.AppendRow(int32(i), "s1", float64(i)/2, &takenAt))
+
+		// Rows become visible to other connections in FlushEvery-sized steps
+		switch i + 1 {
+		case 99:
+			assert.Equal(t, int64(0), countReadings(t, db))
+		case 100, 200:
+			assert.Equal(t, int64(i+1), countReadings(t, db))
+		}
+	}
+	assert.Equal(t, int64(200), countReadings(t, db))
+
+	// Close flushes the remaining partial batch
+	require.NoError(t, appender.Close())
+	assert.Equal(t, int64(250), countReadings(t, db))
+
+	var last struct {
+		ID      int
+		Value   float64
+		TakenAt time.Time
+	}
+	require.NoError(t, db.Table("readings").Order("id DESC").Limit(1).Scan(&last).Error)
+	assert.Equal(t, 249, last.ID)
+	assert.InDelta(t, 124.5, last.Value, 0.0001)
+	assert.True(t, takenAt.Equal(last.TakenAt))
+}
+
+func TestAppender_ManualFlush(t *testing.T) {
+	db := setupAppenderTestDB(t)
+
+	appender, err := duckdb.NewAppender(db, "readings", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, appender.AppendRow(int32(1), "s1", 1.5, time.Now()))
+	require.NoError(t, appender.AppendRow(int32(2), nil, 2.5, nil))
+	require.NoError(t, appender.Flush())
+	assert.Equal(t, int64(2), countReadings(t, db))
+
+	require.NoError(t, appender.Close())
+	assert.Equal(t, int64(2), countReadings(t, db))
+}
+
+func TestAppender_UnknownTable(t *testing.T) {
+	db := setupAppenderTestDB(t)
+
+	_, err := duckdb.NewAppender(db, "missing_table", nil)
+	assert.Error(t, err)
+
+	// The connection taken for the failed appender is returned to the pool
+	assert.Equal(t, int64(0), countReadings(t, db))
+}
+
+type AppendedReading struct {
+	ID        uint `gorm:"primaryKey"`
+	Sensor    string
+	Value     *float64
+	CreatedAt time.Time
+}
+
+func TestAppender_Model(t *testing.T) {
+	db := setupAppenderTestDB(t)
+	require.NoError(t, db.AutoMigrate(&AppendedReading{}))
+	require.NoError(t, db.Create(&AppendedReading{Sensor: "seed"}).Error)
+
+	appender, err := duckdb.NewAppender(db.Model(&AppendedReading{}), "", nil)
+	require.NoError(t, err)
+
+	value := 2.5
+	takenAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	readings := []AppendedReading{
+		{Sensor: "s1", Value: &value},
+		{Sensor: "s2"},
+		{ID: 100, Sensor: "s3", CreatedAt: takenAt},
+	}
+	require.NoError(t, appender.AppendModel(readings))
+	require.NoError(t, appender.AppendModel(&AppendedReading{Sensor: "s4"}))
+	require.NoError(t, appender.Close())
+
+	// Zero keys continue the table's sequence and are written back
+	assert.Equal(t, uint(2), readings[0].ID)
+	assert.Equal(t, uint(3), readings[1].ID)
+	assert.Equal(t, uint(100), readings[2].ID)
+	assert.False(t, readings[0].CreatedAt.IsZero())
+
+	var stored []AppendedReading
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 5)
+	assert.Equal(t, []string{"seed", "s1", "s2", "s4", "s3"}, []string{stored[0].Sensor, stored[1].Sensor, stored[2].Sensor, stored[3].Sensor, stored[4].Sensor})
+	require.NotNil(t, stored[1].Value)
+	assert.InDelta(t, 2.5, *stored[1].Value, 0.0001)
+	assert.Nil(t, stored[2].Value)
+	assert.False(t, stored[1].CreatedAt.IsZero())
+	assert.True(t, takenAt.Equal(stored[4].CreatedAt))
+
+	t.Run("RequiresModel", func(t *testing.T) {
+		appender, err := duckdb.NewAppender(db, "readings", nil)
+		require.NoError(t, err)
+		defer appender.Close()
+		assert.Error(t, appender.AppendModel(&AppendedReading{}))
+
+		_, err = duckdb.NewAppender(db, "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestBulkInsert(t *testing.T) {
+	db := setupAppenderTestDB(t)
+	require.NoError(t, db.AutoMigrate(&AppendedReading{}))
+
+	value := 1.5
+	readings := []AppendedReading{{Sensor: "a", Value: &value}, {Sensor: "b"}, {Sensor: "c"}}
+	inserted, err := duckdb.BulkInsert(db, &readings)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), inserted)
+	assert.Equal(t, []uint{1, 2, 3}, []uint{readings[0].ID, readings[1].ID, readings[2].ID})
+
+	// Pointers to structs; keys continue the sequence, which reserves no more
+	// values than there are rows, so later inserts leave no gap
+	more := []*AppendedReading{{Sensor: "d"}, {Sensor: "e"}}
+	inserted, err = duckdb.BulkInsert(db, more)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+	assert.Equal(t, []uint{4, 5}, []uint{more[0].ID, more[1].ID})
+
+	created := AppendedReading{Sensor: "f"}
+	require.NoError(t, db.Create(&created).Error)
+	assert.Equal(t, uint(6), created.ID)
+
+	var stored []AppendedReading
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 6)
+	assert.Equal(t, "e", stored[4].Sensor)
+	require.NotNil(t, stored[0].Value)
+	assert.InDelta(t, 1.5, *stored[0].Value, 0.0001)
+	assert.False(t, stored[2].CreatedAt.IsZero())
+
+	t.Run("Empty", func(t *testing.T) {
+		inserted, err := duckdb.BulkInsert(db, []AppendedReading{})
+		require.NoError(t, err)
+		assert.Zero(t, inserted)
+	})
+
+	t.Run("NotASlice", func(t *testing.T) {
+		_, err := duckdb.BulkInsert(db, &AppendedReading{Sensor: "x"})
+		assert.Error(t, err)
+	})
+}
+
+// BenchmarkBulkInsert compares the native appender with multi-row INSERTs
+// for loading a large slice of models
+func BenchmarkBulkInsert(b *testing.B) {
+	const rows = 10000
+
+	newReadings := func() []AppendedReading {
+		readings := make([]AppendedReading, rows)
+		for i := range readings {
+			value := float64(i)
+			readings[i] = AppendedReading{Sensor: "sensor", Value: &value}
+		}
+		return readings
+	}
+
+	for _, loader := range []struct {
+		name string
+		load func(db *gorm.DB, readings []AppendedReading) error
+	}{
+		{"BulkInsert", func(db *gorm.DB, readings []AppendedReading) error {
+			_, err := duckdb.BulkInsert(db, &readings)
+			return err
+		}},
+		{"CreateInBatches", func(db *gorm.DB, readings []AppendedReading) error {
+			return db.CreateInBatches(&readings, 1000).Error
+		}},
+	} {
+		b.Run(loader.name, func(b *testing.B) {
+			db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := db.AutoMigrate(&AppendedReading{}); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := loader.load(db, newReadings()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
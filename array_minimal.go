@@ -124,6 +124,11 @@ func (sas *SimpleArrayScanner) Scan(value interface{}) error {
 				} else {
 					return fmt.Errorf("expected bool, got %T at index %d", elem, i)
 				}
+			case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Interface:
+				// Nested LISTs, MAPs and STRUCTs
+				if err := decodeNested(elem, elemValue.Addr().Interface()); err != nil {
+					return fmt.Errorf("failed to decode element %d: %w", i, err)
+				}
 			default:
 				return fmt.Errorf("unsupported target element type: %v", elemType.Kind())
 			}
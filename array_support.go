@@ -1,10 +1,17 @@
 package duckdb
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 
 	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StringArray represents a DuckDB string array using native Composite type
@@ -22,6 +29,13 @@ type IntArray struct {
 	duckdb.Composite[[]int64]
 }
 
+// HugeIntArray represents a DuckDB HUGEINT array using native Composite type.
+// Elements are *big.Int so values beyond the int64 range keep full precision;
+// NULL elements scan as nil.
+type HugeIntArray struct {
+	duckdb.Composite[[]*big.Int]
+}
+
 // GormDataType returns the GORM data type for StringArray.
 // GormDataType returns the GORM data type for StringArray.
 func (StringArray) GormDataType() string {
@@ -38,6 +52,11 @@ func (FloatArray) GormDataType() string {
 	return "DOUBLE[]"
 }
 
+// GormDataType returns the GORM data type for HugeIntArray.
+func (HugeIntArray) GormDataType() string {
+	return "HUGEINT[]"
+}
+
 // Value implementations for driver.Valuer interface
 func (a StringArray) Value() (driver.Value, error) {
 	values := a.Get()
@@ -65,6 +84,36 @@ func (a FloatArray) Value() (driver.Value, error) {
 	return values, nil
 }
 
+// Value implements driver.Valuer interface for HugeIntArray.
+func (a HugeIntArray) Value() (driver.Value, error) {
+	values := a.Get()
+	if values == nil {
+		return []*big.Int{}, nil // Return empty slice instead of nil
+	}
+	return values, nil
+}
+
+// GormValue implements gorm.Valuer for HugeIntArray. go-duckdb cannot bind a
+// list holding NULLs, so an array with nil elements is written element by
+// element, nil elements as NULL.
+func (a HugeIntArray) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	values := a.Get()
+	elements := make([]string, len(values))
+	vars := make([]interface{}, 0, len(values))
+	for i, v := range values {
+		if v == nil {
+			elements[i] = nullValue
+			continue
+		}
+		elements[i] = "?"
+		vars = append(vars, v)
+	}
+	if len(vars) == len(values) {
+		return clause.Expr{SQL: "?", Vars: []interface{}{listParam{values}}}
+	}
+	return clause.Expr{SQL: "[" + strings.Join(elements, ", ") + "]", Vars: vars}
+}
+
 // Scan implementations for sql.Scanner interface
 func (a *StringArray) Scan(value interface{}) error {
 	if err := a.Composite.Scan(value); err != nil {
@@ -89,6 +138,14 @@ func (a *FloatArray) Scan(value interface{}) error {
 	return nil
 }
 
+// Scan implements sql.Scanner interface for HugeIntArray.
+func (a *HugeIntArray) Scan(value interface{}) error {
+	if err := a.Composite.Scan(value); err != nil {
+		return fmt.Errorf("failed to scan hugeint array: %w", err)
+	}
+	return nil
+}
+
 // NewStringArray creates a new StringArray from a slice of strings.
 func NewStringArray(values []string) StringArray {
 	var arr StringArray
@@ -109,3 +166,415 @@ func NewFloatArray(values []float64) FloatArray {
 	_ = arr.Scan(values)
 	return arr
 }
+
+// NewHugeIntArray creates a new HugeIntArray from a slice of *big.Int values.
+func NewHugeIntArray(values []*big.Int) HugeIntArray {
+	var arr HugeIntArray
+	_ = arr.Scan(values)
+	return arr
+}
+
+// Array query helpers
+//
+// The helpers below build DuckDB list function calls as clause.Expr values, so
+// they can be bound into Select, Where, Order or Update expressions:
+//
+//	db.Model(&Post{}).Select("id, ? AS tags", duckdb.ListSort("tags", "DESC")).Scan(&posts)
+//	db.Model(&post).Update("tags", duckdb.ListDistinct("tags"))
+
+// ListSort returns list_sort(column, order). order is "ASC" or "DESC"
+// (case-insensitive); an empty order sorts ascending and any other value is
+// ignored in favour of DuckDB's default order.
+func ListSort(column string, order string) clause.Expr {
+	switch strings.ToUpper(strings.TrimSpace(order)) {
+	case "", "ASC":
+		return clause.Expr{SQL: "list_sort(?, 'ASC')", Vars: []interface{}{clause.Column{Name: column}}}
+	case "DESC":
+		return clause.Expr{SQL: "list_sort(?, 'DESC')", Vars: []interface{}{clause.Column{Name: column}}}
+	default:
+		// Fall back to DuckDB's default order rather than inlining unknown input
+		return clause.Expr{SQL: "list_sort(?)", Vars: []interface{}{clause.Column{Name: column}}}
+	}
+}
+
+// ListDistinct returns list_distinct(column), which removes duplicates and
+// NULLs. DuckDB does not preserve element order, so sort the result (e.g.
+// list_sort(list_distinct(tags))) when order matters.
+func ListDistinct(column string) clause.Expr {
+	return clause.Expr{SQL: "list_distinct(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ArrayAppend returns list_concat(column, [values...]), the column with values
+// added at the end. Use it with Update to extend a list column in place:
+//
+//	db.Model(&post).Update("tags", duckdb.ArrayAppend("tags", "duckdb"))
+//
+// Without values the column is returned unchanged.
+func ArrayAppend(column string, values ...interface{}) clause.Expr {
+	if len(values) == 0 {
+		return clause.Expr{SQL: "?", Vars: []interface{}{clause.Column{Name: column}}}
+	}
+	vars := append([]interface{}{clause.Column{Name: column}}, values...)
+	return clause.Expr{SQL: "list_concat(?, " + listLiteralPlaceholders(len(values)) + ")", Vars: vars}
+}
+
+// ArrayPrepend returns list_concat([values...], column), the column with
+// values added at the front. Without values the column is returned unchanged.
+func ArrayPrepend(column string, values ...interface{}) clause.Expr {
+	if len(values) == 0 {
+		return clause.Expr{SQL: "?", Vars: []interface{}{clause.Column{Name: column}}}
+	}
+	vars := append(append([]interface{}{}, values...), clause.Column{Name: column})
+	return clause.Expr{SQL: "list_concat(" + listLiteralPlaceholders(len(values)) + ", ?)", Vars: vars}
+}
+
+// listLiteralPlaceholders returns a list literal of n bind placeholders; lists
+// are bound element by element since database/sql cannot bind Go slices
+func listLiteralPlaceholders(n int) string {
+	return "[" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + "]"
+}
+
+// ListSum returns list_sum(column), the sum of the list's elements. NULL
+// elements are skipped; an empty list yields NULL.
+func ListSum(column string) clause.Expr {
+	return clause.Expr{SQL: "list_sum(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ListMax returns list_max(column), the largest element.
+func ListMax(column string) clause.Expr {
+	return clause.Expr{SQL: "list_max(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ListMin returns list_min(column), the smallest element.
+func ListMin(column string) clause.Expr {
+	return clause.Expr{SQL: "list_min(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ListReduce returns list_reduce(column, lambda), folding the list from the
+// left with a two-argument DuckDB lambda such as "(acc, x) -> acc * x". The
+// lambda is written as SQL, so it must not contain untrusted input. Reducing
+// an empty list is an error in DuckDB.
+func ListReduce(column string, lambda string) clause.Expr {
+	return clause.Expr{SQL: "list_reduce(?, " + lambda + ")", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// Array filters
+//
+// ArrayContains and ArrayOverlaps are clause.Expression conditions on a list
+// column, for use in Where, Not or Or:
+//
+//	db.Where(duckdb.ArrayContains("tags", "go")).Find(&posts)
+//	db.Where(duckdb.ArrayOverlaps("scores", []interface{}{90, 95.5})).Find(&runs)
+//
+// Values are bound as parameters, so string, integer and float elements are
+// compared against the column's element type by DuckDB.
+
+// ArrayContains is the condition list_contains(column, value), true when the
+// list column has an element equal to value
+func ArrayContains(column string, value interface{}) clause.Expression {
+	return arrayContains{column: column, value: value}
+}
+
+type arrayContains struct {
+	column string
+	value  interface{}
+}
+
+func (c arrayContains) Build(builder clause.Builder) {
+	builder.WriteString("list_contains(")
+	builder.WriteQuoted(clause.Column{Name: c.column})
+	builder.WriteString(", ")
+	builder.AddVar(builder, c.value)
+	builder.WriteByte(')')
+}
+
+// ArrayOverlaps is the condition column && [values...], true when the list
+// column shares at least one element with values. An empty values matches no
+// rows.
+func ArrayOverlaps(column string, values []interface{}) clause.Expression {
+	return arrayOverlaps{column: column, values: values}
+}
+
+type arrayOverlaps struct {
+	column string
+	values []interface{}
+}
+
+func (o arrayOverlaps) Build(builder clause.Builder) {
+	if len(o.values) == 0 {
+		builder.WriteString("FALSE")
+		return
+	}
+	builder.WriteQuoted(clause.Column{Name: o.column})
+	builder.WriteString(" && [")
+	for i, value := range o.values {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.AddVar(builder, value)
+	}
+	builder.WriteByte(']')
+}
+
+// ListContains is the condition list_contains(column, value), the same as
+// ArrayContains.
+func ListContains(column string, value interface{}) clause.Expression {
+	return arrayContains{column: column, value: value}
+}
+
+// ListHasAny returns list_has_any(column, values), true when the list column
+// shares at least one element with values. values is a Go slice such as
+// []string or []int64, bound as a single DuckDB list:
+//
+//	db.Where(duckdb.ListHasAny("tags", []string{"go", "rust"})).Find(&posts)
+func ListHasAny(column string, values interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_has_any(?, ?)", Vars: []interface{}{clause.Column{Name: column}, listParam{values}}}
+}
+
+// ListHasAll returns list_has_all(column, values), true when every element of
+// values is in the list column; an empty values matches every row.
+func ListHasAll(column string, values interface{}) clause.Expr {
+	return clause.Expr{SQL: "list_has_all(?, ?)", Vars: []interface{}{clause.Column{Name: column}, listParam{values}}}
+}
+
+// listParam binds a Go slice as one list parameter; GORM would otherwise
+// expand it into a parenthesised list of placeholders. go-duckdb types the
+// list from the slice's elements, so []int binds to a BIGINT[] column.
+type listParam struct {
+	values interface{}
+}
+
+func (p listParam) Value() (driver.Value, error) {
+	return p.values, nil
+}
+
+// listValue returns value as a list expression when it is a Go slice
+func listValue(value interface{}) interface{} {
+	if isSlice(value) {
+		return listLiteralExpr(reflect.ValueOf(value))
+	}
+	return value
+}
+
+// listLiteralExpr renders a Go slice as a DuckDB list; a nil slice is NULL.
+// Slices of plain values bind as one list parameter. go-duckdb cannot bind
+// lists holding NULLs, and binds *time.Time as text, so slices of pointers,
+// interfaces and nested slices or maps are written element by element, nil
+// elements as NULL.
+func listLiteralExpr(rv reflect.Value) clause.Expr {
+	if rv.IsNil() {
+		return clause.Expr{SQL: nullValue}
+	}
+	switch rv.Type().Elem().Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+	default:
+		return clause.Expr{SQL: "?", Vars: []interface{}{listParam{rv.Interface()}}}
+	}
+
+	elements := make([]string, rv.Len())
+	vars := make([]interface{}, 0, rv.Len())
+	for i := range elements {
+		elem := rv.Index(i)
+		for (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+
+		switch {
+		case (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && elem.IsNil():
+			elements[i] = nullValue
+			continue
+		case elem.Kind() == reflect.Map:
+			vars = append(vars, mapLiteralExpr(elem))
+		case isSlice(elem.Interface()):
+			vars = append(vars, listLiteralExpr(elem))
+		default:
+			vars = append(vars, elem.Interface())
+		}
+		elements[i] = "?"
+	}
+	return clause.Expr{SQL: "[" + strings.Join(elements, ", ") + "]", Vars: vars}
+}
+
+// listRows scans DuckDB lists into plain Go slice destinations such as
+// *[]time.Time, which database/sql cannot assign a []interface{} to. It also
+// converts between flag columns and destinations database/sql cannot
+// assign: BOOLEAN into integer fields, and integer flags other than 0 and 1
+// into bool fields (any non-zero value is true).
+type listRows struct {
+	*sql.Rows
+	columnTypes []*sql.ColumnType
+}
+
+func (r *listRows) Scan(dest ...interface{}) error {
+	lists := make(map[int]*interface{})
+	flags := make(map[int]*interface{})
+	args := dest
+	for i, d := range dest {
+		isList := isListDest(d)
+		if !isList && !r.isFlagDest(i, d) {
+			continue
+		}
+		if len(lists)+len(flags) == 0 {
+			args = append([]interface{}(nil), dest...)
+		}
+		holder := new(interface{})
+		args[i] = holder
+		if isList {
+			lists[i] = holder
+		} else {
+			flags[i] = holder
+		}
+	}
+
+	if err := r.Rows.Scan(args...); err != nil {
+		return err
+	}
+
+	for i, list := range lists {
+		target := reflect.ValueOf(dest[i]).Elem()
+		if *list == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+		target = allocatePointers(target)
+		if err := decodeNested(*list, target.Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to scan list column: %w", err)
+		}
+	}
+	for i, flag := range flags {
+		if err := assignFlag(dest[i], *flag); err != nil {
+			return fmt.Errorf("failed to scan flag column: %w", err)
+		}
+	}
+	return nil
+}
+
+// isFlagDest reports whether column index is BOOLEAN and dest an integer, or
+// the column an integer and dest a bool
+func (r *listRows) isFlagDest(index int, dest interface{}) bool {
+	kind := destKind(dest)
+	if kind != reflect.Bool && !isIntegerKind(kind) {
+		return false
+	}
+
+	if r.columnTypes == nil {
+		columnTypes, err := r.Rows.ColumnTypes()
+		if err != nil {
+			return false
+		}
+		r.columnTypes = columnTypes
+	}
+	if index >= len(r.columnTypes) {
+		return false
+	}
+
+	switch r.columnTypes[index].DatabaseTypeName() {
+	case "BOOLEAN":
+		return isIntegerKind(kind)
+	case "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "HUGEINT",
+		"UTINYINT", "USMALLINT", "UINTEGER", "UBIGINT", "UHUGEINT":
+		return kind == reflect.Bool
+	}
+	return false
+}
+
+// destKind returns the kind dest points to through any pointers, or Invalid
+// when dest scans itself
+func destKind(dest interface{}) reflect.Kind {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return reflect.Invalid
+	}
+	t := rv.Type()
+	for t.Kind() == reflect.Ptr {
+		if t.Implements(scannerType) {
+			return reflect.Invalid
+		}
+		t = t.Elem()
+	}
+	return t.Kind()
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// allocatePointers points each pointer level of target at a new value, as
+// database/sql does for pointer destinations, and returns the value at the
+// end. Writing through an existing pointer would change values scanned
+// earlier: GORM reuses its scan destinations across rows and keeps the
+// pointers they held.
+func allocatePointers(target reflect.Value) reflect.Value {
+	for target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+	return target
+}
+
+// assignFlag stores a scanned BOOLEAN or integer value in the bool or integer
+// dest points to; NULL sets dest to its zero value
+func assignFlag(dest interface{}, value interface{}) error {
+	target := reflect.ValueOf(dest).Elem()
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	target = allocatePointers(target)
+
+	if target.Kind() == reflect.Bool {
+		rv := reflect.ValueOf(value)
+		switch {
+		case rv.CanInt():
+			target.SetBool(rv.Int() != 0)
+		case rv.CanUint():
+			target.SetBool(rv.Uint() != 0)
+		default:
+			huge, ok := value.(*big.Int)
+			if !ok {
+				return fmt.Errorf("cannot convert %T to bool", value)
+			}
+			target.SetBool(huge.Sign() != 0)
+		}
+		return nil
+	}
+
+	flag, ok := value.(bool)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to %s", value, target.Type())
+	}
+	var n uint64
+	if flag {
+		n = 1
+	}
+	if target.CanInt() {
+		target.SetInt(int64(n)) //nolint:gosec // n is 0 or 1
+	} else {
+		target.SetUint(n)
+	}
+	return nil
+}
+
+// isListDest reports whether dest points, possibly through further pointers,
+// to a Go slice that is not []byte and does not scan itself
+func isListDest(dest interface{}) bool {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return false
+	}
+	t := rv.Type()
+	for t.Kind() == reflect.Ptr {
+		if t.Implements(scannerType) {
+			return false
+		}
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
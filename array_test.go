@@ -1,7 +1,10 @@
 package duckdb_test
 
 import (
+	"math"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,10 +32,6 @@ func setupArrayTestDB(t *testing.T) *gorm.DB {
 }
 
 func TestNativeArrayFunctionality(t *testing.T) {
-	// Skip this test for now due to issues with GORM Scan and Raw queries
-	// The underlying array functionality is tested in other test files
-	t.Skip("Skipping due to GORM Raw().Scan() issues with array queries")
-	
 	// Test basic array creation without DB queries
 	stringValues := []string{"hello", "world", "test"}
 	stringArr := duckdb.NewStringArray(stringValues)
@@ -77,73 +76,83 @@ func TestNativeArray_Scan(t *testing.T) {
 }
 
 func TestArrays_DatabaseIntegration(t *testing.T) {
-	t.Skip("Skipping due to GORM Raw().Scan() issues with array structures")
-
 	db := setupArrayTestDB(t)
 
-	t.Run("Native Array Insert and Retrieve", func(t *testing.T) {
-		// Test data using constructor functions
-		stringValues := []string{"software", "analytics", "business"}
-		floatValues := []float64{4.5, 4.8, 4.2, 4.9}
-		intValues := []int64{1250, 890, 2340, 567}
-
-		// Create record using Raw SQL since native arrays work best with Raw queries
-		err := db.Exec(`
-			INSERT INTO test_array_models (string_arr, float_arr, int_arr) 
-			VALUES (?, ?, ?)
-		`, stringValues, floatValues, intValues).Error
-		require.NoError(t, err)
+	t.Run("Create and First", func(t *testing.T) {
+		stringValues := []string{"software", "duck's db", `back\slash`, "a,b", ""}
+		floatValues := []float64{4.5, 0.1, 1e-20, 123456789.123456789}
+		intValues := []int64{1250, math.MinInt64, math.MaxInt64}
 
-		// Retrieve record using Raw SQL with Scan
-		var retrieved TestArrayModel
-		err = db.Raw("SELECT id, string_arr, float_arr, int_arr FROM test_array_models ORDER BY id DESC LIMIT 1").Scan(&retrieved).Error
-		require.NoError(t, err)
-		assert.NotZero(t, retrieved.ID)
+		original := TestArrayModel{
+			StringArr: duckdb.NewStringArray(stringValues),
+			FloatArr:  duckdb.NewFloatArray(floatValues),
+			IntArr:    duckdb.NewIntArray(intValues),
+		}
+		require.NoError(t, db.Create(&original).Error)
+		assert.NotZero(t, original.ID)
 
-		// Verify arrays were stored and retrieved correctly
+		var retrieved TestArrayModel
+		require.NoError(t, db.First(&retrieved, original.ID).Error)
 		assert.Equal(t, stringValues, retrieved.StringArr.Get())
 		assert.Equal(t, floatValues, retrieved.FloatArr.Get())
 		assert.Equal(t, intValues, retrieved.IntArr.Get())
 	})
 
-	t.Run("Array Update Operations", func(t *testing.T) {
-		// Test update using Raw SQL
-		initialStringValues := []string{"test1", "test2"}
-		initialFloatValues := []float64{1.0, 2.0}
-		initialIntValues := []int64{10, 20}
+	t.Run("Empty arrays", func(t *testing.T) {
+		var empty TestArrayModel
+		require.NoError(t, db.Create(&empty).Error)
 
-		// Insert initial record
-		err := db.Exec(`
-			INSERT INTO test_array_models (string_arr, float_arr, int_arr) 
-			VALUES (?, ?, ?)
-		`, initialStringValues, initialFloatValues, initialIntValues).Error
-		require.NoError(t, err)
+		var retrieved TestArrayModel
+		require.NoError(t, db.First(&retrieved, empty.ID).Error)
+		assert.Empty(t, retrieved.StringArr.Get())
+		assert.Empty(t, retrieved.IntArr.Get())
+	})
 
-		// Get the inserted record ID
-		var insertedID uint
-		err = db.Raw("SELECT id FROM test_array_models ORDER BY id DESC LIMIT 1").Scan(&insertedID).Error
-		require.NoError(t, err)
+	t.Run("Batch create and Find", func(t *testing.T) {
+		batch := []TestArrayModel{
+			{StringArr: duckdb.NewStringArray([]string{"x"}), IntArr: duckdb.NewIntArray([]int64{1})},
+			{StringArr: duckdb.NewStringArray([]string{"y", "z"}), IntArr: duckdb.NewIntArray([]int64{2, 3})},
+		}
+		require.NoError(t, db.Create(&batch).Error)
+
+		var found []TestArrayModel
+		require.NoError(t, db.Where("id IN ?", []uint{batch[0].ID, batch[1].ID}).Order("id").Find(&found).Error)
+		require.Len(t, found, 2)
+		assert.Equal(t, []string{"y", "z"}, found[1].StringArr.Get())
+		assert.Equal(t, []int64{2, 3}, found[1].IntArr.Get())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		record := TestArrayModel{StringArr: duckdb.NewStringArray([]string{"test1", "test2"})}
+		require.NoError(t, db.Create(&record).Error)
 
-		// Update with new values
 		newStringValues := []string{"updated1", "updated2", "updated3"}
 		newFloatValues := []float64{3.0, 4.0, 5.0}
-		newIntValues := []int64{30, 40, 50}
-
-		err = db.Exec(`
-			UPDATE test_array_models 
-			SET string_arr = ?, float_arr = ?, int_arr = ? 
-			WHERE id = ?
-		`, newStringValues, newFloatValues, newIntValues, insertedID).Error
+		err := db.Model(&record).Updates(TestArrayModel{
+			StringArr: duckdb.NewStringArray(newStringValues),
+			FloatArr:  duckdb.NewFloatArray(newFloatValues),
+		}).Error
 		require.NoError(t, err)
+		require.NoError(t, db.Model(&record).Update("int_arr", duckdb.NewIntArray([]int64{30, 40})).Error)
 
-		// Verify update using Raw SQL
 		var updated TestArrayModel
-		err = db.Raw("SELECT id, string_arr, float_arr, int_arr FROM test_array_models WHERE id = ?", insertedID).Scan(&updated).Error
-		require.NoError(t, err)
-
+		require.NoError(t, db.First(&updated, record.ID).Error)
 		assert.Equal(t, newStringValues, updated.StringArr.Get())
 		assert.Equal(t, newFloatValues, updated.FloatArr.Get())
-		assert.Equal(t, newIntValues, updated.IntArr.Get())
+		assert.Equal(t, []int64{30, 40}, updated.IntArr.Get())
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		err := db.Exec("INSERT INTO test_array_models (string_arr, float_arr, int_arr) VALUES (?, ?, ?)",
+			duckdb.NewStringArray([]string{"raw"}), duckdb.NewFloatArray([]float64{1.5}), duckdb.NewIntArray([]int64{7})).Error
+		require.NoError(t, err)
+
+		var retrieved TestArrayModel
+		require.NoError(t, db.Raw("SELECT id, string_arr, float_arr, int_arr FROM test_array_models WHERE string_arr = ?",
+			duckdb.NewStringArray([]string{"raw"})).Scan(&retrieved).Error)
+		assert.Equal(t, []string{"raw"}, retrieved.StringArr.Get())
+		assert.Equal(t, []float64{1.5}, retrieved.FloatArr.Get())
+		assert.Equal(t, []int64{7}, retrieved.IntArr.Get())
 	})
 }
 
@@ -166,3 +175,279 @@ func TestArrayConstructors(t *testing.T) {
 		assert.Equal(t, values, arr.Get())
 	})
 }
+
+func TestArrayQueryHelpers(t *testing.T) {
+	db := setupTestDB(t)
+
+	require.NoError(t, db.Exec("CREATE TABLE tagged_posts (id INTEGER, tags INTEGER[])").Error)
+	require.NoError(t, db.Exec("INSERT INTO tagged_posts VALUES (1, [3, 1, 3, 2, 1]), (2, [5, NULL, 5])").Error)
+
+	type post struct {
+		ID   int
+		Tags duckdb.IntArray
+	}
+
+	t.Run("ListSort", func(t *testing.T) {
+		var asc post
+		err := db.Table("tagged_posts").Select("id, ? AS tags", duckdb.ListSort("tags", "asc")).Where("id = ?", 1).Scan(&asc).Error
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 1, 2, 3, 3}, asc.Tags.Get())
+
+		var desc post
+		err = db.Table("tagged_posts").Select("id, ? AS tags", duckdb.ListSort("tags", "DESC")).Where("id = ?", 1).Scan(&desc).Error
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 3, 2, 1, 1}, desc.Tags.Get())
+	})
+
+	t.Run("ListDistinct", func(t *testing.T) {
+		var posts []post
+		err := db.Table("tagged_posts").Select("id, ? AS tags", duckdb.ListDistinct("tags")).Order("id").Scan(&posts).Error
+		require.NoError(t, err)
+		require.Len(t, posts, 2)
+		assert.ElementsMatch(t, []int64{1, 2, 3}, posts[0].Tags.Get())
+		assert.Equal(t, []int64{5}, posts[1].Tags.Get())
+	})
+
+	t.Run("NormalizeInPlace", func(t *testing.T) {
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Update("tags", duckdb.ListDistinct("tags")).Error)
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Update("tags", duckdb.ListSort("tags", "")).Error)
+
+		var normalized post
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Scan(&normalized).Error)
+		assert.Equal(t, []int64{1, 2, 3}, normalized.Tags.Get())
+	})
+
+	t.Run("AppendPrepend", func(t *testing.T) {
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Update("tags", duckdb.ArrayAppend("tags", 4, 5)).Error)
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Update("tags", duckdb.ArrayPrepend("tags", 0)).Error)
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Update("tags", duckdb.ArrayAppend("tags")).Error)
+
+		var extended post
+		require.NoError(t, db.Table("tagged_posts").Where("id = ?", 1).Scan(&extended).Error)
+		assert.Equal(t, []int64{0, 1, 2, 3, 4, 5}, extended.Tags.Get())
+	})
+
+	t.Run("Reductions", func(t *testing.T) {
+		require.NoError(t, db.Exec("CREATE TABLE feature_rows (id INTEGER, samples INTEGER[])").Error)
+		require.NoError(t, db.Exec("INSERT INTO feature_rows VALUES (1, [4, 1, 3]), (2, [2, NULL, 5]), (3, [])").Error)
+
+		type stats struct {
+			ID      int
+			Total   *int64
+			Largest *int64
+			Least   *int64
+		}
+		var rows []stats
+		err := db.Table("feature_rows").
+			Select("id, ? AS total, ? AS largest, ? AS least", duckdb.ListSum("samples"), duckdb.ListMax("samples"), duckdb.ListMin("samples")).
+			Order("id").Scan(&rows).Error
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Equal(t, int64(8), *rows[0].Total)
+		assert.Equal(t, int64(4), *rows[0].Largest)
+		assert.Equal(t, int64(1), *rows[0].Least)
+		assert.Equal(t, int64(7), *rows[1].Total, "NULL elements are skipped")
+		assert.Nil(t, rows[2].Total)
+		assert.Nil(t, rows[2].Largest)
+
+		var product int64
+		err = db.Table("feature_rows").Select("?", duckdb.ListReduce("samples", "(acc, x) -> acc * x")).
+			Where("id = ?", 1).Scan(&product).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), product)
+	})
+
+	t.Run("AppendStrings", func(t *testing.T) {
+		type labelled struct {
+			ID     uint `gorm:"primaryKey"`
+			Labels duckdb.StringArray
+		}
+		require.NoError(t, db.Exec("CREATE TABLE labelled_posts (id INTEGER, labels VARCHAR[])").Error)
+		require.NoError(t, db.Exec("INSERT INTO labelled_posts VALUES (1, ['go'])").Error)
+
+		require.NoError(t, db.Table("labelled_posts").Where("id = ?", 1).Update("labels", duckdb.ArrayAppend("labels", "duck's db")).Error)
+
+		var row labelled
+		require.NoError(t, db.Table("labelled_posts").Where("id = ?", 1).Scan(&row).Error)
+		assert.Equal(t, []string{"go", "duck's db"}, row.Labels.Get())
+	})
+}
+
+func TestArrayFilters(t *testing.T) {
+	db := setupTestDB(t)
+
+	require.NoError(t, db.Exec("CREATE TABLE filtered_posts (id INTEGER, labels VARCHAR[], counts BIGINT[], scores DOUBLE[])").Error)
+	require.NoError(t, db.Exec(`INSERT INTO filtered_posts VALUES
+		(1, ['go', 'duckdb'], [1, 2], [0.5, 1.5]),
+		(2, ['rust'], [3], [2.5]),
+		(3, [], [], [])`).Error)
+
+	ids := func(t *testing.T, query interface{}, args ...interface{}) []int {
+		t.Helper()
+		var result []int
+		require.NoError(t, db.Table("filtered_posts").Where(query, args...).Order("id").Pluck("id", &result).Error)
+		return result
+	}
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.Equal(t, []int{1}, ids(t, duckdb.ArrayContains("labels", "go")))
+		assert.Equal(t, []int{2}, ids(t, duckdb.ArrayContains("counts", 3)))
+		assert.Equal(t, []int{1}, ids(t, duckdb.ArrayContains("scores", 1.5)))
+		assert.Empty(t, ids(t, duckdb.ArrayContains("labels", "java")))
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2}, ids(t, duckdb.ArrayOverlaps("labels", []interface{}{"duckdb", "rust"})))
+		assert.Equal(t, []int{2}, ids(t, duckdb.ArrayOverlaps("counts", []interface{}{int64(3), 9})))
+		assert.Equal(t, []int{2}, ids(t, duckdb.ArrayOverlaps("scores", []interface{}{2.5})))
+		assert.Empty(t, ids(t, duckdb.ArrayOverlaps("labels", nil)))
+	})
+
+	t.Run("ListHasAnyAll", func(t *testing.T) {
+		assert.Equal(t, []int{1}, ids(t, duckdb.ListContains("labels", "duckdb")))
+		assert.Equal(t, []int{1, 2}, ids(t, duckdb.ListHasAny("labels", []string{"go", "rust", "java"})))
+		assert.Equal(t, []int{1}, ids(t, duckdb.ListHasAll("labels", []string{"go", "duckdb"})))
+		assert.Empty(t, ids(t, duckdb.ListHasAll("labels", []string{"go", "rust"})))
+		assert.Equal(t, []int{1, 2, 3}, ids(t, duckdb.ListHasAll("labels", []string{})))
+
+		assert.Equal(t, []int{1}, ids(t, duckdb.ListHasAll("counts", []int{1, 2})), "[]int binds to BIGINT[]")
+		assert.Equal(t, []int{2}, ids(t, duckdb.ListHasAny("counts", []int32{3, 4})))
+		assert.Equal(t, []int{1, 2}, ids(t, duckdb.ListHasAny("scores", []float64{1.5, 2.5})))
+		assert.Equal(t, []int{2}, ids(t, duckdb.ListHasAny("labels", []interface{}{"rust"})))
+		assert.Equal(t, []int{2}, ids(t, duckdb.ListHasAll("labels", duckdb.NewStringArray([]string{"rust"}).Get())))
+	})
+
+	t.Run("Compose", func(t *testing.T) {
+		var result []int
+		err := db.Table("filtered_posts").
+			Not(duckdb.ArrayContains("labels", "go")).
+			Where("id < ?", 3).
+			Pluck("id", &result).Error
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, result)
+
+		stmt := db.Session(&gorm.Session{DryRun: true}).Table("filtered_posts").
+			Where(duckdb.ArrayContains("labels", "go")).Find(&[]map[string]interface{}{}).Statement
+		assert.Contains(t, stmt.SQL.String(), `list_contains("labels", ?)`)
+		assert.Equal(t, []interface{}{"go"}, stmt.Vars)
+	})
+}
+
+type TimelineEvent struct {
+	ID        uint         `gorm:"primaryKey"`
+	Occurred  []time.Time  `gorm:"type:timestamp[]"`
+	Reminders []*time.Time `gorm:"type:timestamp[]"`
+}
+
+func TestTimeSlices(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TimelineEvent{}))
+
+	first := time.Date(2024, 3, 1, 9, 30, 0, 123456000, time.UTC)
+	second := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		event := TimelineEvent{Occurred: []time.Time{first, second}, Reminders: []*time.Time{&second, nil}}
+		require.NoError(t, db.Create(&event).Error)
+
+		var found TimelineEvent
+		require.NoError(t, db.First(&found, event.ID).Error)
+		require.Len(t, found.Occurred, 2)
+		assert.True(t, found.Occurred[0].Equal(first), "got %v", found.Occurred[0])
+		assert.True(t, found.Occurred[1].Equal(second), "got %v", found.Occurred[1])
+		require.Len(t, found.Reminders, 2)
+		require.NotNil(t, found.Reminders[0])
+		assert.True(t, found.Reminders[0].Equal(second))
+		assert.Nil(t, found.Reminders[1])
+	})
+
+	t.Run("EmptyAndNil", func(t *testing.T) {
+		event := TimelineEvent{Occurred: []time.Time{}}
+		require.NoError(t, db.Create(&event).Error)
+
+		var found TimelineEvent
+		require.NoError(t, db.First(&found, event.ID).Error)
+		assert.NotNil(t, found.Occurred)
+		assert.Empty(t, found.Occurred)
+		assert.Nil(t, found.Reminders)
+	})
+
+	t.Run("BatchAndUpdate", func(t *testing.T) {
+		events := []TimelineEvent{
+			{Occurred: []time.Time{first}},
+			{Occurred: []time.Time{second}, Reminders: []*time.Time{&first}},
+		}
+		require.NoError(t, db.Create(&events).Error)
+		require.NoError(t, db.Model(&events[0]).Update("occurred", []time.Time{second, first}).Error)
+
+		var found []TimelineEvent
+		require.NoError(t, db.Where("id IN ?", []uint{events[0].ID, events[1].ID}).Order("id").Find(&found).Error)
+		require.Len(t, found, 2)
+		require.Len(t, found[0].Occurred, 2)
+		assert.True(t, found[0].Occurred[0].Equal(second))
+		assert.True(t, found[0].Occurred[1].Equal(first))
+		require.Len(t, found[1].Reminders, 1)
+		assert.True(t, found[1].Reminders[0].Equal(first))
+	})
+}
+
+type HugeIntArrayModel struct {
+	ID       uint                `gorm:"primaryKey"`
+	Counters duckdb.HugeIntArray `json:"counters"`
+}
+
+func TestHugeIntArray(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&HugeIntArrayModel{}))
+
+	maxHugeInt, ok := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	require.True(t, ok)
+	minHugeInt, ok := new(big.Int).SetString("-170141183460469231731687303715884105728", 10)
+	require.True(t, ok)
+	beyondInt64 := new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))
+
+	t.Run("GormDataType", func(t *testing.T) {
+		assert.Equal(t, "HUGEINT[]", duckdb.HugeIntArray{}.GormDataType())
+	})
+
+	t.Run("ScanPreservesPrecision", func(t *testing.T) {
+		var arr duckdb.HugeIntArray
+		err := db.Raw("SELECT [?::HUGEINT, ?::HUGEINT, NULL, 42::HUGEINT]",
+			maxHugeInt.String(), minHugeInt.String()).Row().Scan(&arr)
+		require.NoError(t, err)
+
+		values := arr.Get()
+		require.Len(t, values, 4)
+		assert.Zero(t, maxHugeInt.Cmp(values[0]), "got %s", values[0])
+		assert.Zero(t, minHugeInt.Cmp(values[1]), "got %s", values[1])
+		assert.Nil(t, values[2])
+		assert.Zero(t, big.NewInt(42).Cmp(values[3]))
+	})
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		record := HugeIntArrayModel{Counters: duckdb.NewHugeIntArray([]*big.Int{beyondInt64, nil, minHugeInt})}
+		require.NoError(t, db.Create(&record).Error)
+
+		var found HugeIntArrayModel
+		require.NoError(t, db.First(&found, record.ID).Error)
+		values := found.Counters.Get()
+		require.Len(t, values, 3)
+		assert.Zero(t, beyondInt64.Cmp(values[0]), "got %s", values[0])
+		assert.Nil(t, values[1])
+		assert.Zero(t, minHugeInt.Cmp(values[2]), "got %s", values[2])
+	})
+
+	t.Run("ValueBindsList", func(t *testing.T) {
+		value, err := duckdb.NewHugeIntArray([]*big.Int{maxHugeInt}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, []*big.Int{maxHugeInt}, value)
+
+		var arr duckdb.HugeIntArray
+		require.NoError(t, db.Raw("SELECT ?", duckdb.NewHugeIntArray([]*big.Int{maxHugeInt, beyondInt64})).Row().Scan(&arr))
+		values := arr.Get()
+		require.Len(t, values, 2)
+		assert.Zero(t, maxHugeInt.Cmp(values[0]), "got %s", values[0])
+		assert.Zero(t, beyondInt64.Cmp(values[1]), "got %s", values[1])
+	})
+}
@@ -0,0 +1,140 @@
+package duckdb
+
+import (
+	"strconv"
+
+	"gorm.io/gorm/clause"
+)
+
+// DuckDB-specific SELECT clauses
+//
+// Qualify, SamplePercent, SampleRows and DistinctOn return clauses for
+// db.Clauses, so they compose with each other and with the rest of the query
+// builder, including inside Scopes:
+//
+//	func latestPerDevice(db *gorm.DB) *gorm.DB {
+//		return db.Clauses(duckdb.Qualify("row_number() OVER (PARTITION BY device_id ORDER BY recorded_at DESC) = 1"))
+//	}
+//
+//	db.Scopes(latestPerDevice).Where("value > ?", 10).Find(&readings)
+//
+// The dialector registers QUALIFY and USING SAMPLE in the query clause order
+// (SELECT, FROM, WHERE, GROUP BY, QUALIFY, USING SAMPLE, ORDER BY, LIMIT), so
+// they are written where DuckDB expects them whatever order they are added in.
+
+// queryClauses is the build order of SELECT statements
+var queryClauses = []string{"SELECT", "FROM", "WHERE", "GROUP BY", "QUALIFY", "USING SAMPLE", "ORDER BY", "LIMIT", "FOR"}
+
+// qualifyClause filters rows on window function results
+type qualifyClause struct {
+	exprs []clause.Expression
+}
+
+// Qualify returns a QUALIFY clause, which filters on window function results
+// the way HAVING filters on aggregates, e.g.
+// Qualify("row_number() OVER (PARTITION BY ? ORDER BY ts DESC) = ?", clause.Column{Name: "key"}, 1).
+// Adding several Qualify clauses combines them with AND.
+func Qualify(query string, args ...interface{}) clause.Interface {
+	return qualifyClause{exprs: []clause.Expression{clause.Expr{SQL: query, Vars: args}}}
+}
+
+func (qualifyClause) Name() string {
+	return "QUALIFY"
+}
+
+func (q qualifyClause) Build(builder clause.Builder) {
+	clause.Where{Exprs: q.exprs}.Build(builder)
+}
+
+func (q qualifyClause) MergeClause(c *clause.Clause) {
+	if existing, ok := c.Expression.(qualifyClause); ok {
+		q.exprs = append(append([]clause.Expression{}, existing.exprs...), q.exprs...)
+	}
+	c.Expression = q
+}
+
+// sampleClause reads a random sample of the FROM rows
+type sampleClause struct {
+	size string
+}
+
+// SamplePercent returns a USING SAMPLE clause keeping about percent% of the
+// rows, each row chosen independently (Bernoulli sampling). The sample is
+// taken before WHERE is applied.
+func SamplePercent(percent float64) clause.Interface {
+	return sampleClause{size: strconv.FormatFloat(percent, 'f', -1, 64) + " PERCENT (bernoulli)"}
+}
+
+// SampleRows returns a USING SAMPLE clause keeping exactly rows rows (or all
+// rows if there are fewer), using reservoir sampling.
+func SampleRows(rows int) clause.Interface {
+	return sampleClause{size: strconv.Itoa(rows) + " ROWS"}
+}
+
+func (sampleClause) Name() string {
+	return "USING SAMPLE"
+}
+
+func (s sampleClause) Build(builder clause.Builder) {
+	builder.WriteString(s.size)
+}
+
+func (s sampleClause) MergeClause(c *clause.Clause) {
+	c.Expression = s
+}
+
+// distinctOnClause prefixes the select list with DISTINCT ON (columns)
+type distinctOnClause struct {
+	columns []string
+}
+
+// DistinctOn returns a SELECT DISTINCT ON (columns) clause, keeping the first
+// row of each group of columns in ORDER BY order. It keeps the select list
+// set by Select (or the model's fields).
+func DistinctOn(columns ...string) clause.Interface {
+	return distinctOnClause{columns: columns}
+}
+
+func (distinctOnClause) Name() string {
+	return "SELECT"
+}
+
+func (d distinctOnClause) Build(builder clause.Builder) {
+	builder.WriteString("DISTINCT ON (")
+	for i, column := range d.columns {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(clause.Column{Name: column})
+	}
+	builder.WriteByte(')')
+}
+
+func (d distinctOnClause) MergeClause(c *clause.Clause) {
+	c.AfterNameExpression = d
+}
+
+// limitClauseBuilder writes LIMIT and OFFSET as integer literals instead of
+// bind variables, so DuckDB plans the statement with constant bounds (e.g.
+// a Top-N for ORDER BY ... LIMIT). Limit and Offset are ints, so inlining
+// them cannot inject SQL.
+func limitClauseBuilder(c clause.Clause, builder clause.Builder) {
+	limit, ok := c.Expression.(clause.Limit)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+
+	hasLimit := limit.Limit != nil && *limit.Limit >= 0
+	if hasLimit {
+		builder.WriteString("LIMIT ")
+		builder.WriteString(strconv.Itoa(*limit.Limit))
+	}
+	if limit.Offset > 0 {
+		if hasLimit {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString("OFFSET ")
+		builder.WriteString(strconv.Itoa(limit.Offset))
+	}
+}
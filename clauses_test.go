@@ -0,0 +1,169 @@
+package duckdb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type ScopedReading struct {
+	ID       uint `gorm:"primaryKey"`
+	DeviceID string
+	Value    int
+}
+
+func setupScopedReadings(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&ScopedReading{}))
+	require.NoError(t, db.Exec("INSERT INTO scoped_readings (id, device_id, value) SELECT i, 'd' || (i % 4), i * 10 FROM range(1, 101) t(i)").Error)
+	return db
+}
+
+// latestPerDevice keeps each device's highest id
+func latestPerDevice(db *gorm.DB) *gorm.DB {
+	return db.Clauses(duckdb.Qualify("row_number() OVER (PARTITION BY ? ORDER BY ? DESC) = ?",
+		clause.Column{Name: "device_id"}, clause.Column{Name: "id"}, 1))
+}
+
+func sampled(rows int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(duckdb.SampleRows(rows))
+	}
+}
+
+func TestClausesWithScopes(t *testing.T) {
+	db := setupScopedReadings(t)
+
+	t.Run("QualifyScope", func(t *testing.T) {
+		var readings []ScopedReading
+		err := db.Scopes(latestPerDevice).Where("value > ?", 0).Order("device_id").Find(&readings).Error
+		require.NoError(t, err)
+		require.Len(t, readings, 4)
+		assert.Equal(t, []uint{100, 97, 98, 99}, []uint{readings[0].ID, readings[1].ID, readings[2].ID, readings[3].ID})
+	})
+
+	t.Run("ComposedScopesSQL", func(t *testing.T) {
+		// Clauses land in DuckDB's order regardless of the order scopes add them
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(&ScopedReading{}).Order("id").Limit(5).Scopes(sampled(50), latestPerDevice).
+				Where("value > ?", 0).Find(&[]ScopedReading{})
+		})
+		where := indexOf(t, sql, "WHERE")
+		qualify := indexOf(t, sql, "QUALIFY")
+		sample := indexOf(t, sql, "USING SAMPLE 50 ROWS")
+		order := indexOf(t, sql, "ORDER BY id")
+		limit := indexOf(t, sql, "LIMIT")
+		assert.True(t, where < qualify && qualify < sample && sample < order && order < limit, sql)
+	})
+
+	t.Run("ComposedScopesRun", func(t *testing.T) {
+		var readings []ScopedReading
+		err := db.Scopes(sampled(100), latestPerDevice).Find(&readings).Error
+		require.NoError(t, err)
+		assert.Len(t, readings, 4)
+
+		var count int64
+		require.NoError(t, db.Model(&ScopedReading{}).Scopes(sampled(10)).Count(&count).Error)
+		assert.Equal(t, int64(10), count)
+	})
+
+	t.Run("MultipleQualifyCombine", func(t *testing.T) {
+		var readings []ScopedReading
+		err := db.Scopes(latestPerDevice, func(tx *gorm.DB) *gorm.DB {
+			return tx.Clauses(duckdb.Qualify("sum(value) OVER (PARTITION BY device_id) > ?", 12500))
+		}).Order("device_id").Find(&readings).Error
+		require.NoError(t, err)
+		require.Len(t, readings, 2)
+		assert.Equal(t, "d0", readings[0].DeviceID)
+		assert.Equal(t, "d3", readings[1].DeviceID)
+	})
+
+	t.Run("DistinctOnWithSelect", func(t *testing.T) {
+		distinctDevices := func(tx *gorm.DB) *gorm.DB {
+			return tx.Clauses(duckdb.DistinctOn("device_id"))
+		}
+
+		var readings []ScopedReading
+		err := db.Scopes(distinctDevices).Order("device_id, value DESC").Find(&readings).Error
+		require.NoError(t, err)
+		require.Len(t, readings, 4)
+		assert.Equal(t, 1000, readings[0].Value)
+
+		var values []struct {
+			DeviceID string
+			Label    string
+		}
+		err = db.Model(&ScopedReading{}).Scopes(distinctDevices).
+			Select("device_id, ? AS label", duckdb.If(gorm.Expr("value > ?", 500), "high", "low")).
+			Order("device_id, value").Scan(&values).Error
+		require.NoError(t, err)
+		require.Len(t, values, 4)
+		assert.Equal(t, "low", values[0].Label)
+	})
+
+	t.Run("SamplePercent", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&ScopedReading{}).Clauses(duckdb.SamplePercent(100)).Count(&count).Error)
+		assert.Equal(t, int64(100), count)
+	})
+}
+
+func indexOf(t *testing.T, s, substr string) int {
+	t.Helper()
+	idx := strings.Index(s, substr)
+	require.GreaterOrEqual(t, idx, 0, "%q not found in %s", substr, s)
+	return idx
+}
+
+func TestLimitOffsetPagination(t *testing.T) {
+	db := setupScopedReadings(t)
+
+	pageSize := 7
+	seen := make(map[uint]bool)
+	for page := 0; ; page++ {
+		var readings []ScopedReading
+		require.NoError(t, db.Order("id").Limit(pageSize).Offset(page*pageSize).Find(&readings).Error)
+		if len(readings) == 0 {
+			break
+		}
+		assert.LessOrEqual(t, len(readings), pageSize)
+		assert.Equal(t, uint(page*pageSize+1), readings[0].ID)
+		for _, reading := range readings {
+			assert.False(t, seen[reading.ID], "id %d returned on two pages", reading.ID)
+			seen[reading.ID] = true
+		}
+	}
+	assert.Len(t, seen, 100)
+
+	t.Run("InlinedSQL", func(t *testing.T) {
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("id").Limit(pageSize).Offset(3 * pageSize).Find(&[]ScopedReading{})
+		})
+		assert.Contains(t, sql, "LIMIT 7 OFFSET 21")
+
+		sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Offset(5).Find(&[]ScopedReading{})
+		})
+		assert.Contains(t, sql, "OFFSET 5")
+		assert.NotContains(t, sql, "LIMIT")
+	})
+
+	t.Run("OffsetOnlyAndFirst", func(t *testing.T) {
+		var tail []ScopedReading
+		require.NoError(t, db.Order("id").Offset(95).Find(&tail).Error)
+		require.Len(t, tail, 5)
+		assert.Equal(t, uint(96), tail[0].ID)
+
+		var first ScopedReading
+		require.NoError(t, db.Order("id DESC").First(&first).Error)
+		assert.Equal(t, uint(100), first.ID)
+	})
+}
@@ -0,0 +1,413 @@
+package duckdb
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// JSONOptions configures ImportJSON and ExportJSON
+type JSONOptions struct {
+	// Array reads or writes a single JSON array of records instead of
+	// newline-delimited JSON (one object per line). On import, leaving it
+	// false lets DuckDB auto-detect either layout.
+	Array bool
+}
+
+// ImportJSON loads a JSON file into an existing table with
+// COPY table FROM 'path' (FORMAT JSON). Object keys are matched to columns by
+// name. Pass nil options for the defaults. It returns the number of rows loaded.
+func ImportJSON(db *gorm.DB, table, path string, options *JSONOptions) (int64, error) {
+	result := db.Session(&gorm.Session{NewDB: true}).Exec(
+		"COPY " + db.Statement.Quote(table) + " FROM " + sqlStringLiteral(path) +
+			" " + jsonCopyOptions(options))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to import JSON from %s into %s: %w", path, table, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ExportJSON writes every row of table to path with
+// COPY table TO 'path' (FORMAT JSON), as newline-delimited JSON unless
+// options.Array is set. Pass nil options for the defaults.
+func ExportJSON(db *gorm.DB, table, path string, options *JSONOptions) error {
+	err := db.Session(&gorm.Session{NewDB: true}).Exec(
+		"COPY " + db.Statement.Quote(table) + " TO " + sqlStringLiteral(path) +
+			" " + jsonCopyOptions(options)).Error
+	if err != nil {
+		return fmt.Errorf("failed to export %s to JSON at %s: %w", table, path, err)
+	}
+	return nil
+}
+
+// jsonCopyOptions renders the COPY option list for JSON import/export
+func jsonCopyOptions(options *JSONOptions) string {
+	copyOptions := []string{"FORMAT JSON"}
+	if options != nil && options.Array {
+		copyOptions = append(copyOptions, "ARRAY true")
+	}
+	return "(" + strings.Join(copyOptions, ", ") + ")"
+}
+
+// CSVImportOptions configures ImportCSV. Empty fields leave the setting to
+// DuckDB's CSV sniffer.
+type CSVImportOptions struct {
+	// Delimiter separating fields, e.g. ";" or "|"
+	Delimiter string
+
+	// DateFormat and TimestampFormat are strftime-style formats for DATE and
+	// TIMESTAMP values, e.g. "%m/%d/%Y" or "%d/%m/%Y %H:%M"
+	DateFormat      string
+	TimestampFormat string
+
+	// ColumnTypes forces the type DuckDB reads a CSV column as, keyed by the
+	// column name in the header, e.g. {"zip": "VARCHAR"} keeps leading zeros
+	ColumnTypes map[string]string
+}
+
+// ImportCSV loads a CSV file into an existing table with
+// INSERT INTO table BY NAME SELECT * FROM read_csv('path', ...), so header
+// columns are matched to table columns by name and may appear in any order.
+// Pass nil options for the defaults. It returns the number of rows loaded.
+func ImportCSV(db *gorm.DB, table, path string, options *CSVImportOptions) (int64, error) {
+	result := db.Session(&gorm.Session{NewDB: true}).Exec(
+		"INSERT INTO " + db.Statement.Quote(table) + " BY NAME SELECT * FROM read_csv(" +
+			sqlStringLiteral(path) + csvReadOptions(options) + ")")
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to import CSV from %s into %s: %w", path, table, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// csvReadOptions renders the named read_csv parameters for options, each
+// prefixed with ", "
+func csvReadOptions(options *CSVImportOptions) string {
+	if options == nil {
+		return ""
+	}
+
+	var params strings.Builder
+	if options.Delimiter != "" {
+		params.WriteString(", delim = " + sqlStringLiteral(options.Delimiter))
+	}
+	if options.DateFormat != "" {
+		params.WriteString(", dateformat = " + sqlStringLiteral(options.DateFormat))
+	}
+	if options.TimestampFormat != "" {
+		params.WriteString(", timestampformat = " + sqlStringLiteral(options.TimestampFormat))
+	}
+	if len(options.ColumnTypes) > 0 {
+		columns := make([]string, 0, len(options.ColumnTypes))
+		for column := range options.ColumnTypes {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		types := make([]string, len(columns))
+		for i, column := range columns {
+			types[i] = sqlStringLiteral(column) + ": " + sqlStringLiteral(options.ColumnTypes[column])
+		}
+		params.WriteString(", types = {" + strings.Join(types, ", ") + "}")
+	}
+	return params.String()
+}
+
+// ReadParquet returns a read_parquet expression for RegisterView reading
+// the Parquet files matching glob, a local path or URL such as
+// "s3://bucket/events/*.parquet".
+func ReadParquet(glob string) string {
+	return "read_parquet(" + sqlStringLiteral(glob) + ")"
+}
+
+// ReadCSV returns a read_csv expression for RegisterView reading the CSV
+// files matching path. Pass nil options to let DuckDB sniff the format.
+func ReadCSV(path string, options *CSVImportOptions) string {
+	return "read_csv(" + sqlStringLiteral(path) + csvReadOptions(options) + ")"
+}
+
+// RegisterView creates (or replaces) the view viewName over a file read
+// expression such as ReadParquet or ReadCSV, so the files can be queried
+// through a model whose TableName is viewName without importing them:
+//
+//	duckdb.RegisterView(db, "trips", duckdb.ReadParquet("s3://bucket/trips/*.parquet"))
+//	db.Where("distance > ?", 10).Find(&trips)
+//
+// The view reads the files on every query. The parquet extension is loaded
+// for read_parquet, and httpfs for http(s), s3, gcs and r2 URLs, through the
+// db's ExtensionManager (or a default one, which installs missing
+// extensions). readExpr is written as SQL, so it must not contain untrusted
+// input.
+func RegisterView(db *gorm.DB, viewName, readExpr string) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if extensions := readExtensions(readExpr); len(extensions) > 0 {
+		manager, err := GetExtensionManager(tx)
+		if err != nil {
+			manager = NewExtensionManager(tx, nil)
+		}
+		if err := manager.LoadExtensions(extensions); err != nil {
+			return fmt.Errorf("failed to register view %s: %w", viewName, err)
+		}
+	}
+
+	if err := tx.Exec("CREATE OR REPLACE VIEW " + tx.Statement.Quote(viewName) + " AS SELECT * FROM " + readExpr).Error; err != nil {
+		return fmt.Errorf("failed to register view %s: %w", viewName, err)
+	}
+	return nil
+}
+
+// readExtensions returns the extensions a file read expression needs
+func readExtensions(readExpr string) []string {
+	var extensions []string
+	lower := strings.ToLower(readExpr)
+	if strings.Contains(lower, "read_parquet(") || strings.Contains(lower, "parquet_scan(") {
+		extensions = append(extensions, ExtensionParquet)
+	}
+	for _, scheme := range []string{"http://", "https://", "s3://", "s3a://", "s3n://", "gcs://", "gs://", "r2://"} {
+		if strings.Contains(lower, "'"+scheme) {
+			extensions = append(extensions, ExtensionHTTPS)
+			break
+		}
+	}
+	return extensions
+}
+
+// ExportFormat is the file format ExportQuery writes
+type ExportFormat string
+
+// Export formats supported by ExportQuery
+const (
+	FormatParquet ExportFormat = "PARQUET"
+	FormatCSV     ExportFormat = "CSV"
+	FormatJSON    ExportFormat = "JSON"
+)
+
+// ExportOptions configures ExportQuery
+type ExportOptions struct {
+	// PerThreadOutput writes one file per thread into the directory
+	// destPath, so large results are written, and can be read back, in
+	// parallel
+	PerThreadOutput bool
+
+	// PartitionBy writes a Hive-partitioned tree of files under the
+	// directory destPath, one directory per value of the columns, e.g.
+	// region=eu/data_0.parquet
+	PartitionBy []string
+}
+
+// ExportQuery writes the result of the query built on db (Model/Table,
+// Select, Where, ...) to destPath with COPY (query) TO 'destPath'
+// (FORMAT format) and returns the files written. COPY does not accept bind
+// parameters, so the query's variables are inlined as exact DuckDB
+// literals. Pass nil options to write a single file.
+func ExportQuery(db *gorm.DB, destPath string, format ExportFormat, options *ExportOptions) ([]string, error) {
+	switch format {
+	case FormatParquet, FormatCSV, FormatJSON:
+	default:
+		return nil, fmt.Errorf("failed to export query: unsupported format %q", format)
+	}
+
+	var rows []map[string]interface{}
+	dryRun := db.Session(&gorm.Session{DryRun: true}).Find(&rows)
+	if dryRun.Error != nil {
+		return nil, fmt.Errorf("failed to build query for export: %w", dryRun.Error)
+	}
+	query, err := inlineVars(dryRun.Statement.SQL.String(), dryRun.Statement.Vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query for export: %w", err)
+	}
+
+	copyOptions := []string{"FORMAT " + string(format), "RETURN_FILES true"}
+	if options != nil && options.PerThreadOutput {
+		copyOptions = append(copyOptions, "PER_THREAD_OUTPUT true")
+	}
+	if options != nil && len(options.PartitionBy) > 0 {
+		columns := make([]string, len(options.PartitionBy))
+		for i, column := range options.PartitionBy {
+			columns[i] = db.Statement.Quote(column)
+		}
+		copyOptions = append(copyOptions, "PARTITION_BY ("+strings.Join(columns, ", ")+")")
+	}
+
+	var count int64
+	var written []interface{}
+	err = db.Session(&gorm.Session{NewDB: true}).Raw(
+		"COPY ("+query+") TO "+sqlStringLiteral(destPath)+" ("+strings.Join(copyOptions, ", ")+")",
+	).Row().Scan(&count, &written)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export query to %s: %w", destPath, err)
+	}
+
+	files := make([]string, len(written))
+	for i, file := range written {
+		files[i] = fmt.Sprint(file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// CSVExportOptions configures StreamCSV
+type CSVExportOptions struct {
+	// Delimiter separating fields (default ',')
+	Delimiter rune
+
+	// OmitHeader skips the header row of column names
+	OmitHeader bool
+
+	// NullString is written for NULL values (default empty)
+	NullString string
+
+	// TimeFormat formats DATE, TIME and TIMESTAMP values (default time.RFC3339Nano)
+	TimeFormat string
+}
+
+// StreamCSV runs the query built on tx (Model/Table, Select, Where, Order, ...)
+// and writes the result to w as CSV, a header row of column names followed by
+// one record per row. Rows are written as they are read, so large results are
+// never held in memory, e.g. when streaming a download to an
+// http.ResponseWriter. Lists, structs and maps are written as JSON. Pass nil
+// options for the defaults.
+func StreamCSV(tx *gorm.DB, w io.Writer, options *CSVExportOptions) error {
+	if options == nil {
+		options = &CSVExportOptions{}
+	}
+	timeFormat := options.TimeFormat
+	if timeFormat == "" {
+		timeFormat = time.RFC3339Nano
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to run query for CSV export: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to read result columns: %w", err)
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("failed to read result column types: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if options.Delimiter != 0 {
+		writer.Comma = options.Delimiter
+	}
+	if !options.OmitHeader {
+		if err := writer.Write(columns); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return fmt.Errorf("failed to scan row for CSV export: %w", err)
+		}
+		for i, value := range values {
+			// go-duckdb scans UUIDs as their raw 16 bytes
+			if raw, ok := value.([]byte); ok && len(raw) == 16 && columnTypes[i].DatabaseTypeName() == "UUID" {
+				var id duckdb.UUID
+				copy(id[:], raw)
+				value = id.String()
+			}
+			if record[i], err = csvField(value, options.NullString, timeFormat); err != nil {
+				return fmt.Errorf("failed to format column %s: %w", columns[i], err)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read rows for CSV export: %w", err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// csvField formats one scanned value as a CSV field
+func csvField(value interface{}, nullString, timeFormat string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nullString, nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(timeFormat), nil
+	case *big.Int:
+		return v.String(), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case bool, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	default:
+		encoded, err := json.Marshal(normalizeJSONValue(v))
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
+
+// sqlStringLiteral quotes s as a DuckDB string literal, for statements such
+// as COPY that do not accept bind parameters
+func sqlStringLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqlIdentifier quotes name as a single DuckDB identifier, keeping dots in
+// the name rather than splitting it into a qualified name
+func sqlIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// normalizeJSONValue converts DuckDB MAP values (map[any]any) nested in
+// lists, structs and maps to string-keyed maps that encoding/json accepts
+func normalizeJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[any]any:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = normalizeJSONValue(item)
+		}
+		return normalized
+	case duckdb.Map:
+		return normalizeJSONValue(map[any]any(v))
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeJSONValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeJSONValue(item)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
@@ -0,0 +1,309 @@
+package duckdb_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type CopyEvent struct {
+	ID        uint `gorm:"primaryKey"`
+	Kind      string
+	Payload   string
+	CreatedAt time.Time
+}
+
+func TestJSONImportExport(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyEvent{}))
+
+	createdAt := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	events := []CopyEvent{
+		{Kind: "click", Payload: "it's quoted", CreatedAt: createdAt},
+		{Kind: "view", Payload: "", CreatedAt: createdAt.Add(time.Hour)},
+		{Kind: "click", Payload: "line\nbreak", CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+	for i := range events {
+		require.NoError(t, db.Create(&events[i]).Error)
+	}
+
+	dir := t.TempDir()
+
+	t.Run("NewlineDelimitedRoundTrip", func(t *testing.T) {
+		path := filepath.Join(dir, "events.jsonl")
+		require.NoError(t, duckdb.ExportJSON(db, "copy_events", path, nil))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 3)
+		for _, line := range lines {
+			assert.True(t, json.Valid([]byte(line)), "line is not a JSON object: %s", line)
+		}
+
+		require.NoError(t, db.Exec("CREATE TABLE copy_events_jsonl AS SELECT * FROM copy_events LIMIT 0").Error)
+		imported, err := duckdb.ImportJSON(db, "copy_events_jsonl", path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), imported)
+
+		var reloaded []CopyEvent
+		require.NoError(t, db.Table("copy_events_jsonl").Order("id").Find(&reloaded).Error)
+		require.Len(t, reloaded, 3)
+		for i := range events {
+			assert.Equal(t, events[i].ID, reloaded[i].ID)
+			assert.Equal(t, events[i].Kind, reloaded[i].Kind)
+			assert.Equal(t, events[i].Payload, reloaded[i].Payload)
+			assert.True(t, events[i].CreatedAt.Equal(reloaded[i].CreatedAt))
+		}
+	})
+
+	t.Run("ArrayRoundTrip", func(t *testing.T) {
+		path := filepath.Join(dir, "events.json")
+		require.NoError(t, duckdb.ExportJSON(db, "copy_events", path, &duckdb.JSONOptions{Array: true}))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var records []map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &records))
+		assert.Len(t, records, 3)
+
+		require.NoError(t, db.Exec("CREATE TABLE copy_events_array AS SELECT * FROM copy_events LIMIT 0").Error)
+		imported, err := duckdb.ImportJSON(db, "copy_events_array", path, &duckdb.JSONOptions{Array: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), imported)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := duckdb.ImportJSON(db, "copy_events", filepath.Join(dir, "missing.jsonl"), nil)
+		assert.Error(t, err)
+	})
+}
+
+type VendorShipment struct {
+	ID        uint `gorm:"primaryKey"`
+	Code      string
+	ShippedOn time.Time `gorm:"type:DATE"`
+	SeenAt    time.Time
+}
+
+func TestImportCSV(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&VendorShipment{}))
+
+	path := filepath.Join(t.TempDir(), "shipments.csv")
+	data := "id;code;shipped_on;seen_at\n" +
+		"1;007;03/14/2024;14/03/2024 10:30\n" +
+		"2;010;12/01/2024;01/12/2024 08:00\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	imported, err := duckdb.ImportCSV(db, "vendor_shipments", path, &duckdb.CSVImportOptions{
+		Delimiter:       ";",
+		DateFormat:      "%m/%d/%Y",
+		TimestampFormat: "%d/%m/%Y %H:%M",
+		ColumnTypes:     map[string]string{"code": "VARCHAR"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), imported)
+
+	var shipments []VendorShipment
+	require.NoError(t, db.Order("id").Find(&shipments).Error)
+	require.Len(t, shipments, 2)
+
+	assert.Equal(t, "007", shipments[0].Code, "type override keeps leading zeros")
+	assert.True(t, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC).Equal(shipments[0].ShippedOn))
+	assert.True(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC).Equal(shipments[1].ShippedOn))
+	assert.True(t, time.Date(2024, 3, 14, 10, 30, 0, 0, time.UTC).Equal(shipments[0].SeenAt))
+	assert.True(t, time.Date(2024, 12, 1, 8, 0, 0, 0, time.UTC).Equal(shipments[1].SeenAt))
+
+	t.Run("WrongFormat", func(t *testing.T) {
+		_, err := duckdb.ImportCSV(db, "vendor_shipments", path, &duckdb.CSVImportOptions{
+			Delimiter:   ";",
+			DateFormat:  "%Y-%m-%d",
+			ColumnTypes: map[string]string{"shipped_on": "DATE"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+type FileTrip struct {
+	ID       int
+	City     string
+	Distance float64
+}
+
+func (FileTrip) TableName() string { return "file_trips" }
+
+type FileStation struct {
+	Code string
+	Name string
+}
+
+func (FileStation) TableName() string { return "file_stations" }
+
+func TestRegisterView(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	dir := t.TempDir()
+
+	parquetDir := filepath.Join(dir, "trips")
+	require.NoError(t, os.MkdirAll(parquetDir, 0o750))
+	require.NoError(t, db.Exec("COPY (SELECT 1 AS id, 'Oslo' AS city, CAST(10 AS DOUBLE) AS distance) TO '"+
+		filepath.Join(parquetDir, "Oslo.parquet")+"' (FORMAT PARQUET)").Error)
+	require.NoError(t, db.Exec("COPY (SELECT 2 AS id, 'Bergen' AS city, CAST(20 AS DOUBLE) AS distance) TO '"+
+		filepath.Join(parquetDir, "Bergen.parquet")+"' (FORMAT PARQUET)").Error)
+
+	require.NoError(t, duckdb.RegisterView(db, "file_trips", duckdb.ReadParquet(filepath.Join(parquetDir, "*.parquet"))))
+
+	var trips []FileTrip
+	require.NoError(t, db.Where("distance > ?", 15).Find(&trips).Error)
+	require.Len(t, trips, 1)
+	assert.Equal(t, FileTrip{ID: 2, City: "Bergen", Distance: 20}, trips[0])
+
+	// Re-registering replaces the view
+	require.NoError(t, duckdb.RegisterView(db, "file_trips", duckdb.ReadParquet(filepath.Join(parquetDir, "Oslo.parquet"))))
+	var count int64
+	require.NoError(t, db.Model(&FileTrip{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	t.Run("CSV", func(t *testing.T) {
+		path := filepath.Join(dir, "stations.csv")
+		require.NoError(t, os.WriteFile(path, []byte("code|name\n007|Central\n010|Harbour\n"), 0o600))
+
+		readExpr := duckdb.ReadCSV(path, &duckdb.CSVImportOptions{Delimiter: "|", ColumnTypes: map[string]string{"code": "VARCHAR"}})
+		require.NoError(t, duckdb.RegisterView(db, "file_stations", readExpr))
+
+		var stations []FileStation
+		require.NoError(t, db.Order("code").Find(&stations).Error)
+		assert.Equal(t, []FileStation{{Code: "007", Name: "Central"}, {Code: "010", Name: "Harbour"}}, stations)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		err := duckdb.RegisterView(db, "missing_trips", duckdb.ReadParquet(filepath.Join(dir, "missing.parquet")))
+		assert.Error(t, err)
+	})
+}
+
+func TestStreamCSV(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec(`CREATE TABLE stream_rows (
+		id INTEGER, name VARCHAR, tags VARCHAR[], token UUID, seen_at TIMESTAMP)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO stream_rows VALUES
+		(1, 'plain', ['a', 'b'], '6ba7b810-9dad-11d1-80b4-00c04fd430c8', TIMESTAMP '2024-03-14 10:30:00'),
+		(2, 'comma, "quoted"', NULL, NULL, NULL)`).Error)
+
+	var buf bytes.Buffer
+	require.NoError(t, duckdb.StreamCSV(db.Table("stream_rows").Order("id"), &buf, nil))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "name", "tags", "token", "seen_at"}, records[0])
+	assert.Equal(t, []string{"1", "plain", `["a","b"]`, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "2024-03-14T10:30:00Z"}, records[1])
+	assert.Equal(t, []string{"2", `comma, "quoted"`, "", "", ""}, records[2])
+
+	t.Run("Options", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := duckdb.StreamCSV(db.Table("stream_rows").Select("id, seen_at").Where("id = ?", 2), &buf, &duckdb.CSVExportOptions{
+			Delimiter:  ';',
+			OmitHeader: true,
+			NullString: "NULL",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "2;NULL\n", buf.String())
+	})
+}
+
+func TestExportQuery(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CopyEvent{}))
+	createdAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	for _, event := range []CopyEvent{
+		{Kind: "click", Payload: "it's here", CreatedAt: createdAt},
+		{Kind: "view", Payload: "home", CreatedAt: createdAt},
+		{Kind: "click", Payload: "docs", CreatedAt: createdAt.Add(time.Hour)},
+	} {
+		require.NoError(t, db.Create(&event).Error)
+	}
+
+	query := db.Model(&CopyEvent{}).Select("kind, payload").
+		Where("kind = ? AND created_at >= ?", "click", createdAt).Order("payload")
+	dir := t.TempDir()
+
+	for _, format := range []duckdb.ExportFormat{duckdb.FormatParquet, duckdb.FormatCSV, duckdb.FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			path := filepath.Join(dir, "clicks."+strings.ToLower(string(format)))
+			files, err := duckdb.ExportQuery(query, path, format, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{path}, files)
+
+			var payloads []string
+			require.NoError(t, duckdb.FromFile(db, path).Order("payload").Pluck("payload", &payloads).Error)
+			assert.Equal(t, []string{"docs", "it's here"}, payloads)
+		})
+	}
+
+	t.Run("Quoting", func(t *testing.T) {
+		path := filepath.Join(dir, "quoted.csv")
+		_, err := duckdb.ExportQuery(db.Model(&CopyEvent{}).Where("payload = ?", "it's here"), path, duckdb.FormatCSV, nil)
+		require.NoError(t, err)
+
+		var count int64
+		require.NoError(t, duckdb.FromFile(db, path).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("SubMillisecondFilter", func(t *testing.T) {
+		require.NoError(t, db.Create(&CopyEvent{Kind: "scroll", Payload: "late", CreatedAt: createdAt.Add(600 * time.Microsecond)}).Error)
+
+		path := filepath.Join(dir, "late.csv")
+		_, err := duckdb.ExportQuery(db.Model(&CopyEvent{}).Where("created_at > ?", createdAt.Add(300*time.Microsecond)).
+			Where("created_at < ?", createdAt.Add(time.Second)), path, duckdb.FormatCSV, nil)
+		require.NoError(t, err)
+
+		var payloads []string
+		require.NoError(t, duckdb.FromFile(db, path).Pluck("payload", &payloads).Error)
+		assert.Equal(t, []string{"late"}, payloads)
+		require.NoError(t, db.Where("kind = ?", "scroll").Delete(&CopyEvent{}).Error)
+	})
+
+	t.Run("PerThreadOutput", func(t *testing.T) {
+		require.NoError(t, db.Exec("SET threads = 4").Error)
+		require.NoError(t, db.Exec("CREATE TABLE export_points AS SELECT i AS id, i % 7 AS bucket FROM range(1000000) t(i)").Error)
+
+		path := filepath.Join(dir, "points")
+		files, err := duckdb.ExportQuery(db.Table("export_points").Where("id >= ?", 0), path, duckdb.FormatParquet,
+			&duckdb.ExportOptions{PerThreadOutput: true})
+		require.NoError(t, err)
+		assert.Greater(t, len(files), 1)
+
+		entries, err := os.ReadDir(path)
+		require.NoError(t, err)
+		assert.Len(t, entries, len(files))
+
+		var count int64
+		require.NoError(t, duckdb.FromFile(db, filepath.Join(path, "*.parquet")).Count(&count).Error)
+		assert.Equal(t, int64(1000000), count)
+	})
+
+	t.Run("PartitionBy", func(t *testing.T) {
+		path := filepath.Join(dir, "by_kind")
+		files, err := duckdb.ExportQuery(db.Model(&CopyEvent{}), path, duckdb.FormatCSV,
+			&duckdb.ExportOptions{PartitionBy: []string{"kind"}})
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Contains(t, files[0], filepath.Join(path, "kind=click"))
+		assert.Contains(t, files[1], filepath.Join(path, "kind=view"))
+	})
+
+	_, err := duckdb.ExportQuery(query, filepath.Join(dir, "clicks.xlsx"), "XLSX", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,212 @@
+package duckdb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// RejectedRow is a slice element CreateIgnoringErrors could not insert
+type RejectedRow struct {
+	// Index is the element's position in the slice
+	Index int
+	// Value points to the element
+	Value interface{}
+	// Err is the error DuckDB reported for the element
+	Err error
+}
+
+// CreateIgnoringErrors inserts the models in value, a pointer to a slice, and
+// skips the ones DuckDB rejects (constraint violations, invalid values)
+// instead of failing the whole batch. It returns the number of rows inserted
+// and the rejected elements, so imperfect data can be quarantined:
+//
+//	inserted, rejected, err := duckdb.CreateIgnoringErrors(db, &readings)
+//
+// The batch is first inserted with a single Create; only when that fails is
+// each element inserted on its own, so Create hooks may run twice for a batch
+// with rejected rows. A failed statement aborts a DuckDB transaction, so db
+// must not be inside one. The returned error is reserved for problems with
+// the call itself, not with individual rows.
+func CreateIgnoringErrors(db *gorm.DB, value interface{}) (int64, []RejectedRow, error) {
+	if db.Error != nil {
+		return 0, nil, db.Error
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return 0, nil, fmt.Errorf("CreateIgnoringErrors expects a pointer to a slice, got %T", value)
+	}
+	slice = slice.Elem()
+	if slice.Len() == 0 {
+		return 0, nil, nil
+	}
+
+	tx := db.Session(&gorm.Session{})
+	if result := tx.Create(value); result.Error == nil {
+		return result.RowsAffected, nil, nil
+	}
+
+	var inserted int64
+	var rejected []RejectedRow
+	for i := 0; i < slice.Len(); i++ {
+		element := slice.Index(i)
+		if element.Kind() != reflect.Ptr {
+			element = element.Addr()
+		}
+
+		result := tx.Create(element.Interface())
+		if result.Error != nil {
+			rejected = append(rejected, RejectedRow{Index: i, Value: element.Interface(), Err: result.Error})
+			continue
+		}
+		inserted += result.RowsAffected
+	}
+	return inserted, rejected, nil
+}
+
+// CreateReturning inserts model, a pointer to a struct, with RETURNING * and
+// scans every column of the new row back into it, so database defaults,
+// generated columns and the primary key are all populated:
+//
+//	event := Event{Name: "signup"}
+//	err := duckdb.CreateReturning(db, &event)
+//
+// As with Create, a zero field is left to the database only when it has a
+// default tag; read-only fields (gorm:"->") such as generated columns are
+// never written and come back from RETURNING.
+func CreateReturning(db *gorm.DB, model interface{}) error {
+	if db.Error != nil {
+		return db.Error
+	}
+
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("CreateReturning expects a pointer to a struct, got %T", model)
+	}
+
+	if err := db.Clauses(clause.Returning{}).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create returning row: %w", err)
+	}
+	return nil
+}
+
+// UpsertResult splits the rows an Upsert affected into inserted and updated
+type UpsertResult struct {
+	Inserted int64
+	Updated  int64
+}
+
+// Upsert creates value (a model, or a slice of models written as one
+// multi-row INSERT) with onConflict, e.g.
+//
+//	duckdb.Upsert(db, &rows, clause.OnConflict{
+//		Columns:   []clause.Column{{Name: "id"}},
+//		DoUpdates: clause.AssignmentColumns([]string{"name", "score"}),
+//	})
+//
+// DuckDB reports one affected-row count covering both inserted and updated
+// rows, so Upsert runs in a transaction and first looks up which of value's
+// conflict keys (onConflict.Columns, or the primary key) already exist: rows
+// with a new key are Inserted and the rest of the affected rows Updated. Rows
+// skipped by DO NOTHING (or a DO UPDATE whose Where does not match) are in
+// neither.
+func Upsert(db *gorm.DB, value interface{}, onConflict clause.OnConflict) (UpsertResult, error) {
+	var result UpsertResult
+	err := db.Transaction(func(tx *gorm.DB) error {
+		rows, existing, err := countExistingKeys(tx, value, onConflict)
+		if err != nil {
+			return err
+		}
+
+		created := tx.Clauses(onConflict).Create(value)
+		if created.Error != nil {
+			return created.Error
+		}
+
+		// DO NOTHING skips every existing key, and the affected-row count
+		// GORM reports for it also covers the skipped rows
+		result.Inserted = rows - existing
+		if !onConflict.DoNothing {
+			result.Updated = created.RowsAffected - result.Inserted
+		}
+		return nil
+	})
+	if err != nil {
+		return UpsertResult{}, fmt.Errorf("failed to upsert: %w", err)
+	}
+	return result, nil
+}
+
+// countExistingKeys returns how many rows value holds and how many of them
+// have a conflict key already in the table. Only the conflict key columns of
+// the batch are looked up, so the cost follows the batch, not the table.
+func countExistingKeys(tx *gorm.DB, value interface{}, onConflict clause.OnConflict) (rows, existing int64, err error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(value); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	keys := stmt.Schema.PrimaryFields
+	if len(onConflict.Columns) > 0 {
+		keys = make([]*schema.Field, len(onConflict.Columns))
+		for i, column := range onConflict.Columns {
+			if keys[i] = stmt.Schema.LookUpField(column.Name); keys[i] == nil {
+				return 0, 0, fmt.Errorf("conflict column %s is not a field of %s", column.Name, stmt.Schema.Name)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return 0, 0, fmt.Errorf("no conflict columns for %s", stmt.Schema.Name)
+	}
+
+	var tuples [][]interface{}
+	collect := func(row reflect.Value) {
+		rows++
+		tuple := make([]interface{}, len(keys))
+		for i, key := range keys {
+			fieldValue, zero := key.ValueOf(tx.Statement.Context, row)
+			// Zero keys with a default are left to the database and never conflict
+			if zero && key.HasDefaultValue {
+				return
+			}
+			tuple[i] = fieldValue
+		}
+		tuples = append(tuples, tuple)
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collect(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		collect(rv)
+	}
+	if len(tuples) == 0 {
+		return rows, 0, nil
+	}
+
+	columns := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = tx.Statement.Quote(key.DBName)
+	}
+	table := tx.Statement.Table
+	if table == "" {
+		table = stmt.Table
+	}
+
+	// Unscoped: soft-deleted rows still occupy their keys
+	err = tx.Session(&gorm.Session{NewDB: true}).Unscoped().Table(table).
+		Where("("+strings.Join(columns, ", ")+") IN ?", tuples).
+		Count(&existing).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to look up existing keys: %w", err)
+	}
+	return rows, existing, nil
+}
@@ -0,0 +1,271 @@
+package duckdb_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type IngestReading struct {
+	ID     uint    `gorm:"primaryKey"`
+	Sensor string  `gorm:"uniqueIndex"`
+	Unit   *string `gorm:"not null"`
+	Value  float64
+}
+
+func TestCreateIgnoringErrors(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&IngestReading{}))
+
+	celsius := "C"
+	t.Run("AllValid", func(t *testing.T) {
+		readings := []IngestReading{
+			{Sensor: "a", Unit: &celsius, Value: 1},
+			{Sensor: "b", Unit: &celsius, Value: 2},
+		}
+		inserted, rejected, err := duckdb.CreateIgnoringErrors(db, &readings)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), inserted)
+		assert.Empty(t, rejected)
+		assert.NotZero(t, readings[1].ID)
+	})
+
+	t.Run("SkipsBadRows", func(t *testing.T) {
+		readings := []IngestReading{
+			{Sensor: "c", Unit: &celsius, Value: 3},
+			{Sensor: "a", Unit: &celsius, Value: 4}, // duplicate sensor
+			{Sensor: "d", Value: 5},                 // missing unit
+			{Sensor: "e", Unit: &celsius, Value: 6},
+		}
+		inserted, rejected, err := duckdb.CreateIgnoringErrors(db, &readings)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), inserted)
+
+		require.Len(t, rejected, 2)
+		assert.Equal(t, 1, rejected[0].Index)
+		assert.Same(t, &readings[1], rejected[0].Value)
+		assert.Error(t, rejected[0].Err)
+		assert.Equal(t, 2, rejected[1].Index)
+		assert.Error(t, rejected[1].Err)
+
+		var sensors []string
+		require.NoError(t, db.Model(&IngestReading{}).Order("sensor").Pluck("sensor", &sensors).Error)
+		assert.Equal(t, []string{"a", "b", "c", "e"}, sensors)
+	})
+
+	t.Run("NotASlice", func(t *testing.T) {
+		_, _, err := duckdb.CreateIgnoringErrors(db, &IngestReading{Sensor: "f", Unit: &celsius})
+		assert.Error(t, err)
+	})
+}
+
+type AuditEntry struct {
+	ID           uint `gorm:"primaryKey"`
+	Action       string
+	Status       string    `gorm:"default:open"`
+	CreatedOn    time.Time `gorm:"default:current_timestamp"`
+	ActionLength int       `gorm:"->"`
+}
+
+func TestCreateReturning(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE SEQUENCE seq_audit_entries_id START 1").Error)
+	require.NoError(t, db.Exec(`CREATE TABLE audit_entries (
+		id BIGINT DEFAULT nextval('seq_audit_entries_id') PRIMARY KEY,
+		action VARCHAR,
+		status VARCHAR DEFAULT 'open',
+		created_on TIMESTAMP DEFAULT current_timestamp,
+		action_length INTEGER GENERATED ALWAYS AS (length(action)) VIRTUAL
+	)`).Error)
+
+	before := time.Now().Add(-time.Minute)
+	entry := AuditEntry{Action: "login"}
+	require.NoError(t, duckdb.CreateReturning(db, &entry))
+	assert.Equal(t, uint(1), entry.ID)
+	assert.Equal(t, "open", entry.Status)
+	assert.True(t, entry.CreatedOn.After(before), "created_on default returned, got %v", entry.CreatedOn)
+	assert.Equal(t, 5, entry.ActionLength)
+
+	closed := AuditEntry{Action: "logout", Status: "closed"}
+	require.NoError(t, duckdb.CreateReturning(db, &closed))
+	assert.Equal(t, uint(2), closed.ID)
+	assert.Equal(t, "closed", closed.Status)
+	assert.Equal(t, 6, closed.ActionLength)
+
+	var stored AuditEntry
+	require.NoError(t, db.First(&stored, entry.ID).Error)
+	assert.Equal(t, entry.ActionLength, stored.ActionLength)
+	assert.WithinDuration(t, entry.CreatedOn, stored.CreatedOn, time.Microsecond)
+
+	t.Run("NotAStruct", func(t *testing.T) {
+		entries := []AuditEntry{{Action: "batch"}}
+		assert.Error(t, duckdb.CreateReturning(db, &entries))
+	})
+}
+
+type SyncedScore struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Score int
+}
+
+func TestBatchUpsert(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&SyncedScore{}))
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "score"}),
+	}
+
+	first := []SyncedScore{{ID: 1, Name: "ann", Score: 10}, {ID: 2, Name: "bob", Score: 20}, {ID: 3, Name: "cid", Score: 30}}
+	result := db.Clauses(onConflict).Create(&first)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(3), result.RowsAffected)
+
+	// Overlapping keys: 2 and 3 are updated from excluded, 4 and 5 inserted
+	second := []SyncedScore{{ID: 2, Name: "bob", Score: 25}, {ID: 3, Name: "cyd", Score: 35}, {ID: 4, Name: "dee", Score: 40}, {ID: 5, Name: "eve", Score: 50}}
+	counts, err := duckdb.Upsert(db, &second, onConflict)
+	require.NoError(t, err)
+	assert.Equal(t, duckdb.UpsertResult{Inserted: 2, Updated: 2}, counts)
+
+	// Re-running the same batch is idempotent
+	counts, err = duckdb.Upsert(db, &second, onConflict)
+	require.NoError(t, err)
+	assert.Equal(t, duckdb.UpsertResult{Inserted: 0, Updated: 4}, counts)
+
+	var scores []SyncedScore
+	require.NoError(t, db.Order("id").Find(&scores).Error)
+	assert.Equal(t, []SyncedScore{
+		{ID: 1, Name: "ann", Score: 10},
+		{ID: 2, Name: "bob", Score: 25},
+		{ID: 3, Name: "cyd", Score: 35},
+		{ID: 4, Name: "dee", Score: 40},
+		{ID: 5, Name: "eve", Score: 50},
+	}, scores)
+
+	t.Run("DoNothing", func(t *testing.T) {
+		rows := []SyncedScore{{ID: 5, Name: "ignored", Score: 0}, {ID: 6, Name: "fay", Score: 60}}
+		counts, err := duckdb.Upsert(db, &rows, clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true})
+		require.NoError(t, err)
+		assert.Equal(t, duckdb.UpsertResult{Inserted: 1, Updated: 0}, counts)
+
+		var name string
+		require.NoError(t, db.Model(&SyncedScore{}).Where("id = ?", 5).Pluck("name", &name).Error)
+		assert.Equal(t, "eve", name)
+	})
+}
+
+type BatchEvent struct {
+	ID     uint `gorm:"primaryKey"`
+	Kind   string
+	Status string `gorm:"default:new"`
+	Note   *string
+}
+
+func TestCreateInBatchesMultiRow(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&BatchEvent{}))
+
+	var statements []string
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:record_insert", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	note := "checked"
+	events := make([]BatchEvent, 7)
+	for i := range events {
+		events[i] = BatchEvent{Kind: fmt.Sprintf("kind-%d", i)}
+		if i%2 == 0 {
+			events[i].Status = "done"
+			events[i].Note = &note
+		}
+	}
+
+	result := db.CreateInBatches(&events, 3)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(7), result.RowsAffected)
+
+	// One INSERT per batch of up to 3 rows
+	require.Len(t, statements, 3)
+	for i, rows := range []int{3, 3, 1} {
+		assert.Equal(t, rows, strings.Count(statements[i], "),(")+1, statements[i])
+	}
+
+	seen := make(map[uint]bool)
+	for _, event := range events {
+		require.NotZero(t, event.ID)
+		assert.False(t, seen[event.ID], "duplicate id %d", event.ID)
+		seen[event.ID] = true
+	}
+
+	var stored []BatchEvent
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 7)
+	for i, event := range stored {
+		assert.Equal(t, events[i].ID, event.ID)
+		if i%2 == 0 {
+			assert.Equal(t, "done", event.Status)
+			require.NotNil(t, event.Note)
+		} else {
+			assert.Equal(t, "new", event.Status, "zero field takes its default")
+			assert.Nil(t, event.Note)
+		}
+	}
+}
+
+// BenchmarkCreateInBatches compares one INSERT per row with CreateInBatches,
+// which writes each batch as a single multi-row INSERT
+func BenchmarkCreateInBatches(b *testing.B) {
+	const rows = 1000
+
+	newEvents := func() []BatchEvent {
+		events := make([]BatchEvent, rows)
+		for i := range events {
+			events[i] = BatchEvent{Kind: fmt.Sprintf("kind-%d", i), Status: "done"}
+		}
+		return events
+	}
+
+	for _, batchSize := range []int{1, 100, 1000} {
+		b.Run(fmt.Sprintf("BatchSize%d", batchSize), func(b *testing.B) {
+			db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := db.AutoMigrate(&BatchEvent{}); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				events := newEvents()
+				if batchSize == 1 {
+					for j := range events {
+						if err := db.Create(&events[j]).Error; err != nil {
+							b.Fatal(err)
+						}
+					}
+					continue
+				}
+				if err := db.CreateInBatches(&events, batchSize).Error; err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
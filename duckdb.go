@@ -4,15 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/big"
 	"os"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/marcboeker/go-duckdb/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
@@ -54,10 +62,128 @@ type Config struct {
 
 	// RowCallbackWorkaround controls whether to apply the GORM RowQuery callback fix
 	// Set to false to disable the workaround if GORM fixes the bug in the future
+	// A session can override it with db.Set(RowCallbackWorkaroundKey, enabled)
 	// Default: true (apply workaround)
 	RowCallbackWorkaround *bool
+
+	// LogRenderedSQL logs every executed statement with its bind variables
+	// inlined (DuckDB-quoted via Explain) under the debug prefix, so a failing
+	// query can be copied straight into the DuckDB CLI.
+	// Default: false
+	LogRenderedSQL bool
+
+	// EmptyStringAsNull writes empty strings as NULL on create and update for
+	// nullable string columns. Columns tagged not null (and primary keys) keep
+	// the empty string.
+	// Default: false
+	EmptyStringAsNull bool
+
+	// StrictTypes rejects create and update values DuckDB would coerce lossily
+	// into their column: fractional floats or out-of-range numbers written to
+	// integer columns, and floats with more decimals than a DECIMAL column's
+	// scale. The statement fails with ErrLossyCoercion naming the field.
+	// Default: false
+	StrictTypes bool
+
+	// WarnOnTimeTruncation logs a warning on create and update when a
+	// time.Time with sub-microsecond precision is written to a TIMESTAMP (or
+	// other microsecond) column, where DuckDB silently drops the nanoseconds.
+	// Tag precision-sensitive fields type:TIMESTAMP_NS to keep them.
+	// Default: false
+	WarnOnTimeTruncation bool
+
+	// Settings are DuckDB configuration options applied with SET on every
+	// connection this dialector opens, e.g. {"memory_limit": "2GB",
+	// "threads": "4"}. They are scoped to this dialector's database, so two
+	// dialectors can use different values in the same process.
+	// Unknown names or invalid values fail the connection with DuckDB's
+	// error. Settings cannot be combined with Conn or a custom DriverName,
+	// since the dialector would not see the connections being opened.
+	Settings map[string]string
+
+	// OnConnect runs on every new connection after Settings are applied, for
+	// per-connection setup such as LOAD or CREATE MACRO. An error fails the
+	// connection attempt.
+	OnConnect func(execer driver.ExecerContext) error
+
+	// CheckpointThreshold sets DuckDB's checkpoint_threshold, the WAL size
+	// (e.g. "256MB") at which a file database automatically checkpoints the
+	// WAL into the database file. Lower it when the .wal file grows too large
+	// between checkpoints; Checkpoint forces one in between, and closing the
+	// database (sql.DB.Close) checkpoints before releasing the file. An entry
+	// for checkpoint_threshold in Settings takes precedence.
+	// Default: "" (DuckDB's default, 16MB)
+	CheckpointThreshold string
+
+	// MemoryLimit sets DuckDB's memory_limit, e.g. "4GB" or "512MiB". Like
+	// Settings it is applied with SET on every connection this dialector
+	// opens. A value that is not a number followed by a byte unit fails
+	// Initialize before the database is opened. An entry for memory_limit in
+	// Settings takes precedence.
+	// Default: "" (DuckDB's default, 80% of system memory)
+	MemoryLimit string
+
+	// Threads sets DuckDB's threads, the number of worker threads queries
+	// use, on every connection this dialector opens. Negative values fail
+	// Initialize. An entry for threads in Settings takes precedence.
+	// Default: 0 (DuckDB's default, the number of CPU cores)
+	Threads int
+
+	// AutoIncrementStrategy selects how CreateTable generates auto-increment
+	// keys. Only StrategySequence is available: DuckDB does not implement
+	// identity columns, so StrategyIdentity fails Initialize with
+	// ErrIdentityUnsupported.
+	// Default: StrategySequence
+	AutoIncrementStrategy AutoIncrementStrategy
+
+	// StatementCacheSize keeps up to this many prepared statements per
+	// connection, keyed by query, and hands the cached statement out again
+	// when the same query is prepared on that connection, skipping DuckDB's
+	// parse and plan. The least recently used statement is closed once the
+	// cache is full, and all of them when the connection closes. It helps
+	// code preparing the same query repeatedly, such as GORM's PrepareStmt
+	// mode across sessions or db.Prepare in a loop. Like Settings it requires
+	// the default driver without Conn. Negative values fail Initialize.
+	// Default: 0 (no cache)
+	StatementCacheSize int
+
+	// MigrationLock serializes AutoMigrate across processes sharing the
+	// database: each run first claims a row in the gorm_duckdb_migration_lock
+	// table and waits while another instance holds it.
+	// Default: false
+	MigrationLock bool
+
+	// MigrationLockTimeout bounds how long AutoMigrate waits for the
+	// migration lock before failing with ErrMigrationLocked. A lock held
+	// longer than this is treated as abandoned by a crashed instance and
+	// taken over.
+	// Default: 0 (one minute)
+	MigrationLockTimeout time.Duration
+
+	// stmtGeneration invalidates the StatementCacheSize caches of the
+	// database Initialize opened; ClearStatementCache bumps it
+	stmtGeneration *atomic.Uint64
 }
 
+// AutoIncrementStrategy is how auto-increment keys are generated
+type AutoIncrementStrategy int
+
+const (
+	// StrategySequence backs each auto-increment key with a
+	// seq_<table>_<column> sequence and a DEFAULT nextval(...) column
+	// default; RETURNING reads the generated key back on create.
+	StrategySequence AutoIncrementStrategy = iota
+
+	// StrategyIdentity would declare keys GENERATED BY DEFAULT AS IDENTITY.
+	// DuckDB's parser rejects identity columns, so the dialector refuses it
+	// rather than failing every CreateTable.
+	StrategyIdentity
+)
+
+// ErrIdentityUnsupported is returned by Initialize for
+// Config.AutoIncrementStrategy StrategyIdentity
+var ErrIdentityUnsupported = errors.New("duckdb: identity columns are not supported, use StrategySequence")
+
 // Open creates a new DuckDB dialector with the given DSN.
 func Open(dsn string) gorm.Dialector {
 	return &Dialector{Config: &Config{DSN: dsn}} // Remove DriverName to use default custom driver
@@ -108,34 +234,190 @@ func (d *convertingDriver) Open(name string) (driver.Conn, error) {
 		return nil, fmt.Errorf("failed to open DuckDB connection with name %s: %w", name, err)
 	}
 	debugLog(" convertingDriver.Open succeeded, returning convertingConn")
-	return &convertingConn{conn}, nil
+	return &convertingConn{Conn: conn}, nil
+}
+
+// OpenConnector implements driver.DriverContext so that every connection in a
+// *sql.DB pool shares a single DuckDB database instance. Without it each pooled
+// connection to ":memory:" would open its own, empty, database. Each dialector
+// gets its own connector, so configuration options in the DSN (e.g.
+// "file.db?memory_limit=2GB&threads=4") stay with that dialector's database
+// instead of leaking to others opened in the same process.
+func (d *convertingDriver) OpenConnector(name string) (driver.Connector, error) {
+	debugLog(" convertingDriver.OpenConnector called with DSN: %s", name)
+	return d.newConnector(name, nil)
+}
+
+// newConnector opens a DuckDB database for name whose connections run connInit
+// when they are created, so per-dialector settings stay with that database
+func (d *convertingDriver) newConnector(name string, connInit func(driver.ExecerContext) error) (*convertingConnector, error) {
+	connector, err := duckdb.NewConnector(name, connInit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DuckDB connector with name %s: %w", name, err)
+	}
+	return &convertingConnector{Connector: connector, driver: d}, nil
+}
+
+// convertingConnector wraps the DuckDB connector so connections it hands out
+// go through the same value conversion as convertingDriver.Open. Close is
+// promoted from *duckdb.Connector and releases the database when the pool closes.
+type convertingConnector struct {
+	*duckdb.Connector
+	driver *convertingDriver
+
+	stmtCacheSize  int            // Config.StatementCacheSize of connections it opens
+	stmtGeneration *atomic.Uint64 // bumped by ClearStatementCache
+}
+
+func (c *convertingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		debugLog(" convertingConnector.Connect failed: %v", err)
+		return nil, fmt.Errorf("failed to connect to DuckDB: %w", err)
+	}
+	converting := &convertingConn{Conn: conn, connector: c.Connector}
+	if c.stmtCacheSize > 0 {
+		converting.stmtCache = newStmtCache(c.stmtCacheSize, c.stmtGeneration)
+	}
+	return converting, nil
+}
+
+func (c *convertingConnector) Driver() driver.Driver {
+	return c.driver
 }
 
+// convertingConn remembers the connector that opened it, which GetConnector
+// hands out; it is nil for connections from convertingDriver.Open.
 type convertingConn struct {
 	driver.Conn
+	connector *duckdb.Connector
+	stmtCache *stmtCache // nil unless Config.StatementCacheSize is set
+}
+
+// Close closes the statements cached on the connection, then the connection
+func (c *convertingConn) Close() error {
+	if c.stmtCache != nil {
+		if err := c.stmtCache.close(); err != nil {
+			debugLog(" failed to close cached statements: %v", err)
+		}
+	}
+	return c.Conn.Close()
+}
+
+// checkoutStmt returns the cached statement for query, if one is free
+func (c *convertingConn) checkoutStmt(query string) driver.Stmt {
+	if c.stmtCache == nil {
+		return nil
+	}
+	if entry := c.stmtCache.checkout(query); entry != nil {
+		debugLog(" reusing cached statement for query: %s", query)
+		return &convertingStmt{Stmt: entry.stmt, cache: c.stmtCache, entry: entry}
+	}
+	return nil
+}
+
+// wrapStmt wraps a freshly prepared stmt, caching it when the cache is on
+func (c *convertingConn) wrapStmt(query string, stmt driver.Stmt) driver.Stmt {
+	if c.stmtCache != nil {
+		if entry := c.stmtCache.add(query, stmt); entry != nil {
+			return &convertingStmt{Stmt: stmt, cache: c.stmtCache, entry: entry}
+		}
+	}
+	return &convertingStmt{Stmt: stmt}
+}
+
+// ErrNoConnector is returned by GetConnector when db's connections do not
+// come from a connector this package opened, e.g. with Config.Conn or a
+// custom DriverName.
+var ErrNoConnector = errors.New("duckdb: database was not opened through the duckdb-gorm connector")
+
+// GetConnector returns the go-duckdb connector behind db, for go-duckdb APIs
+// that work on it or on the driver connections it opens, e.g. Connect
+// followed by duckdb.NewAppenderFromConn. Its connections share db's
+// database. The connector belongs to db: closing it closes the database for
+// every connection in the pool.
+func GetConnector(db *gorm.DB) (*duckdb.Connector, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying database: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(statementContext(db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	var connector *duckdb.Connector
+	if err := conn.Raw(func(driverConn interface{}) error {
+		if c, ok := driverConn.(*convertingConn); ok {
+			connector = c.connector
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to inspect connection: %w", err)
+	}
+	if connector == nil {
+		return nil, ErrNoConnector
+	}
+	return connector, nil
+}
+
+// CheckNamedValue passes Go slices, and driver.Valuer types such as
+// StringArray whose Value is a slice, through to go-duckdb, which binds them
+// as DuckDB lists. *big.Int is passed through too, go-duckdb binds it as
+// HUGEINT. database/sql's default conversion rejects both, so everything
+// else is left to it.
+func (c *convertingConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if v, ok := nv.Value.(*big.Int); ok && v != nil {
+		return nil
+	}
+	if valuer, ok := nv.Value.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(valuer); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return driver.ErrSkip
+		}
+		value, err := valuer.Value()
+		if err != nil {
+			return fmt.Errorf("failed to get value of %T: %w", nv.Value, err)
+		}
+		if isSlice(value) {
+			return nil
+		}
+		return driver.ErrSkip
+	}
+	if isSlice(nv.Value) {
+		return nil
+	}
+	return driver.ErrSkip
 }
 
 func (c *convertingConn) Prepare(query string) (driver.Stmt, error) {
 	debugLog(" Prepare called with query: %s", query)
+	if cached := c.checkoutStmt(query); cached != nil {
+		return cached, nil
+	}
 	stmt, err := c.Conn.Prepare(query)
 	if err != nil {
 		debugLog(" Prepare failed: %v", err)
-		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+		return nil, fmt.Errorf("failed to prepare statement: %w", translateDriverError(err))
 	}
 	debugLog(" Prepare succeeded, returning convertingStmt")
-	return &convertingStmt{stmt}, nil
+	return c.wrapStmt(query, stmt), nil
 }
 
 func (c *convertingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
 	debugLog(" PrepareContext called with query: %s", query)
+	if cached := c.checkoutStmt(query); cached != nil {
+		return cached, nil
+	}
 	if prepCtx, ok := c.Conn.(driver.ConnPrepareContext); ok {
 		stmt, err := prepCtx.PrepareContext(ctx, query)
 		if err != nil {
 			debugLog(" PrepareContext failed: %v", err)
-			return nil, fmt.Errorf("failed to prepare statement with context: %w", err)
+			return nil, fmt.Errorf("failed to prepare statement with context: %w", contextDriverError(ctx, err))
 		}
 		debugLog(" PrepareContext succeeded, returning convertingStmt")
-		return &convertingStmt{stmt}, nil
+		return c.wrapStmt(query, stmt), nil
 	}
 	debugLog(" PrepareContext falling back to Prepare")
 	return c.Prepare(query)
@@ -167,7 +449,7 @@ func (c *convertingConn) ExecContext(ctx context.Context, query string, args []d
 		result, err := execCtx.ExecContext(ctx, query, convertedArgs)
 		if err != nil {
 			errorLog(" ExecContext failed: %v", err)
-			return nil, translateDriverError(err)
+			return nil, contextDriverError(ctx, err)
 		}
 		debugLog(" ExecContext succeeded for query: %s", query)
 		return result, nil
@@ -215,10 +497,10 @@ func (c *convertingConn) QueryContext(ctx context.Context, query string, args []
 		rows, err := queryCtx.QueryContext(ctx, query, convertedArgs)
 		if err != nil {
 			errorLog(" QueryContext failed: %v", err)
-			return nil, translateDriverError(err)
+			return nil, contextDriverError(ctx, err)
 		}
 		debugLog(" QueryContext returned rows: %v (nil: %t)", rows, rows == nil)
-		return rows, nil
+		return &convertingRows{rows}, nil
 	}
 	debugLog(" QueryContext: Falling back to non-context version for query: %s", query)
 	values := make([]driver.Value, len(args))
@@ -233,14 +515,68 @@ func (c *convertingConn) QueryContext(ctx context.Context, query string, args []
 			return nil, translateDriverError(err)
 		}
 		debugLog(" Query fallback succeeded for query: %s", query)
-		return rows, nil
+		return &convertingRows{rows}, nil
 	}
 	errorLog(" QueryContext: underlying driver does not support Query operations for query: %s", query)
 	return nil, fmt.Errorf("underlying driver does not support Query operations")
 }
 
+// convertingRows wraps DuckDB result rows so (*sql.Rows).ColumnTypes reports
+// DuckDB type names, scan types, nullability and DECIMAL precision/scale
+type convertingRows struct {
+	driver.Rows
+}
+
+// ColumnTypeDatabaseTypeName returns the DuckDB type, e.g. VARCHAR or DECIMAL(10,2)
+func (r *convertingRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeScanType returns the Go type go-duckdb scans the column into
+func (r *convertingRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+// ColumnTypeNullable reports nullability as unknown: DuckDB does not carry
+// NOT NULL constraints into query results. Migrator.ColumnTypes reads it from
+// the catalog instead.
+func (r *convertingRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return false, false
+}
+
+// ColumnTypePrecisionScale parses the precision and scale of DECIMAL columns
+func (r *convertingRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	matches := decimalTypePattern.FindStringSubmatch(r.ColumnTypeDatabaseTypeName(index))
+	if matches == nil {
+		return 0, 0, false
+	}
+	precision, _ = strconv.ParseInt(matches[1], 10, 64)
+	scale, _ = strconv.ParseInt(matches[2], 10, 64)
+	return precision, scale, true
+}
+
+// decimalTypePattern matches DuckDB's DECIMAL(p,s) type names
+var decimalTypePattern = regexp.MustCompile(`^DECIMAL\((\d+),\s*(\d+)\)$`)
+
+// convertingStmt converts arguments like convertingConn. A statement from
+// the connection's stmtCache is checked back in on Close instead of closed.
 type convertingStmt struct {
 	driver.Stmt
+	cache *stmtCache
+	entry *cachedStmt
+}
+
+func (s *convertingStmt) Close() error {
+	if s.entry != nil {
+		return s.cache.release(s.entry)
+	}
+	return s.Stmt.Close()
 }
 
 func (s *convertingStmt) Exec(args []driver.Value) (driver.Result, error) {
@@ -284,7 +620,7 @@ func (s *convertingStmt) ExecContext(ctx context.Context, args []driver.NamedVal
 		result, err := stmtCtx.ExecContext(ctx, convertedArgs)
 		if err != nil {
 			debugLog(" convertingStmt.ExecContext failed: %v", err)
-			return nil, fmt.Errorf("failed to execute statement with context: %w", err)
+			return nil, fmt.Errorf("failed to execute statement with context: %w", contextDriverError(ctx, err))
 		}
 		debugLog(" convertingStmt.ExecContext succeeded")
 		return result, nil
@@ -299,7 +635,7 @@ func (s *convertingStmt) ExecContext(ctx context.Context, args []driver.NamedVal
 	result, err := s.Stmt.Exec(values)
 	if err != nil {
 		debugLog(" convertingStmt.ExecContext fallback failed: %v", err)
-		return nil, fmt.Errorf("failed to execute statement: %w", err)
+		return nil, fmt.Errorf("failed to execute statement: %w", translateDriverError(err))
 	}
 	debugLog(" convertingStmt.ExecContext fallback succeeded")
 	return result, nil
@@ -313,10 +649,10 @@ func (s *convertingStmt) QueryContext(ctx context.Context, args []driver.NamedVa
 		rows, err := stmtCtx.QueryContext(ctx, convertedArgs)
 		if err != nil {
 			debugLog(" StmtQueryContext failed: %v", err)
-			return nil, fmt.Errorf("failed to query statement with context: %w", err)
+			return nil, fmt.Errorf("failed to query statement with context: %w", contextDriverError(ctx, err))
 		}
 		debugLog(" StmtQueryContext returned rows: %v (nil: %t)", rows, rows == nil)
-		return rows, nil
+		return &convertingRows{rows}, nil
 	}
 	debugLog(" Using fallback Stmt.Query")
 	// Direct fallback without using deprecated methods
@@ -329,10 +665,10 @@ func (s *convertingStmt) QueryContext(ctx context.Context, args []driver.NamedVa
 	rows, err := s.Stmt.Query(values)
 	if err != nil {
 		debugLog(" Stmt.Query failed: %v", err)
-		return nil, fmt.Errorf("failed to query statement: %w", err)
+		return nil, fmt.Errorf("failed to query statement: %w", translateDriverError(err))
 	}
 	debugLog(" Stmt.Query returned rows: %v (nil: %t)", rows, rows == nil)
-	return rows, nil
+	return &convertingRows{rows}, nil
 }
 
 // Convert driver.NamedValue slice
@@ -349,17 +685,18 @@ func convertNamedValues(args []driver.NamedValue) []driver.NamedValue {
 				converted[i].Value = *timePtr
 			}
 		} else if isSlice(arg.Value) {
-			// Convert Go slices to DuckDB array format
-			if arrayStr, err := formatSliceForDuckDB(arg.Value); err == nil {
-				converted[i].Value = arrayStr
-			}
+			// go-duckdb binds a bare slice against the parameter's resolved
+			// type and panics when element types differ (e.g. []int for
+			// BIGINT[]); behind a Valuer it infers the list type from the
+			// elements and lets DuckDB cast
+			converted[i].Value = listParam{arg.Value}
 		}
 	}
 
 	return converted
 }
 
-// isSlice checks if a value is a slice (but not string or []byte)
+// isSlice checks if a value is a slice (but not a byte slice)
 func isSlice(v interface{}) bool {
 	if v == nil {
 		return false
@@ -370,13 +707,21 @@ func isSlice(v interface{}) bool {
 		return false
 	}
 
-	// Don't treat strings or []byte as arrays
-	switch v.(type) {
-	case string, []byte:
+	// Byte slices, including named ones such as JSON and json.RawMessage,
+	// are BLOB or text values rather than lists
+	return rv.Type().Elem().Kind() != reflect.Uint8
+}
+
+// isUUIDType reports whether t (or the type it points to) is a UUID type
+// named UUID over [16]byte, such as github.com/google/uuid.UUID
+func isUUIDType(t reflect.Type) bool {
+	if t == nil {
 		return false
-	default:
-		return true
 	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name() == "UUID" && t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
 }
 
 // Initialize implements gorm.Dialector
@@ -408,6 +753,10 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 	}()
 
 	if !alreadyRegistered {
+		// Register GORM's stock callbacks first so Exec, Update, Delete and Raw
+		// have processors; the DuckDB-specific replacements below build on them.
+		callbacks.RegisterDefaultCallbacks(db, defaultCallbackConfig)
+
 		// Custom CREATE callback to work around GORM v1.31.1 issue where gorm:create
 		// doesn't generate INSERT SQL for DuckDB dialector
 		if err := db.Callback().Create().Replace("gorm:create", duckdbCreateCallback); err != nil {
@@ -454,22 +803,43 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 		}
 		*/
 
-		// Replace the row callback with our DuckDB-compatible version
+		// Replace the row callback with one that runs our DuckDB-compatible version
 		// This is a workaround for a GORM bug where the default RowQuery callback
 		// fails to properly assign Statement.Dest, causing Raw().Row() to return nil.
+		// Whether it applies is decided per statement (see RowCallbackWorkaroundKey).
 		// See: docs/GORM_ROW_CALLBACK_BUG_ANALYSIS.md
-		if shouldApplyRowCallbackFix(db) {
-			if err := db.Callback().Row().Replace("gorm:row", rowQueryCallback); err != nil {
-				if !strings.Contains(strings.ToLower(err.Error()), "duplicated") && !strings.Contains(strings.ToLower(err.Error()), "already") {
-					// Log warning but don't fail initialization - fall back to default callback
-					log.Printf("[WARNING] Failed to replace row callback, using default GORM callback: %v", err)
-					log.Printf("[WARNING] This may cause Raw().Row() to return nil. See GORM_ROW_CALLBACK_BUG_ANALYSIS.md")
-				}
-			} else {
-				debugLog(" Successfully applied RowQuery callback workaround for GORM bug")
+		if err := db.Callback().Row().Replace("gorm:row", rowCallback); err != nil {
+			if !strings.Contains(strings.ToLower(err.Error()), "duplicated") && !strings.Contains(strings.ToLower(err.Error()), "already") {
+				// Log warning but don't fail initialization - fall back to default callback
+				log.Printf("[WARNING] Failed to replace row callback, using default GORM callback: %v", err)
+				log.Printf("[WARNING] This may cause Raw().Row() to return nil. See GORM_ROW_CALLBACK_BUG_ANALYSIS.md")
 			}
 		} else {
-			debugLog(" GORM version appears to have fixed RowQuery callback, using default implementation")
+			debugLog(" Successfully registered RowQuery callback workaround for GORM bug")
+		}
+
+		registerAfterExecute(db, "duckdb:record_statement", recordStatementCallback)
+		if dialector.LogRenderedSQL {
+			registerAfterExecute(db, "duckdb:log_rendered_sql", logRenderedSQLCallback)
+		}
+
+		db.ClauseBuilders["LIMIT"] = limitClauseBuilder
+		db.ClauseBuilders["VALUES"] = listValuesClauseBuilder(db.ClauseBuilders["VALUES"])
+		db.ClauseBuilders["SET"] = listValuesClauseBuilder(db.ClauseBuilders["SET"])
+
+		if dialector.EmptyStringAsNull {
+			db.ClauseBuilders["VALUES"] = emptyStringAsNullClauseBuilder(db.ClauseBuilders["VALUES"])
+			db.ClauseBuilders["SET"] = emptyStringAsNullClauseBuilder(db.ClauseBuilders["SET"])
+		}
+
+		if dialector.StrictTypes {
+			db.ClauseBuilders["VALUES"] = strictTypesClauseBuilder(db.ClauseBuilders["VALUES"])
+			db.ClauseBuilders["SET"] = strictTypesClauseBuilder(db.ClauseBuilders["SET"])
+		}
+
+		if dialector.WarnOnTimeTruncation {
+			db.ClauseBuilders["VALUES"] = timeTruncationClauseBuilder(db.ClauseBuilders["VALUES"])
+			db.ClauseBuilders["SET"] = timeTruncationClauseBuilder(db.ClauseBuilders["SET"])
 		}
 
 		// Attempt to mark this DB instance as having registered callbacks; ignore
@@ -488,8 +858,36 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 		dialector.DriverName = "duckdb-gorm"
 	}
 
+	if err := dialector.validateConnectionOptions(); err != nil {
+		return err
+	}
+
+	initConnections := len(dialector.connectionSettings()) > 0 || dialector.OnConnect != nil
+	ownConnector := initConnections || dialector.StatementCacheSize > 0
+	if ownConnector && (dialector.Conn != nil || dialector.DriverName != "duckdb-gorm") {
+		// Settings are applied as each connection opens, which only works
+		// for pools this dialector opens itself
+		return fmt.Errorf("settings, OnConnect and StatementCacheSize require the dialector to open the database with the default driver, got Conn=%t DriverName=%q",
+			dialector.Conn != nil, dialector.DriverName)
+	}
+
 	if dialector.Conn != nil {
 		db.ConnPool = dialector.Conn
+	} else if ownConnector {
+		var connInit func(driver.ExecerContext) error
+		if initConnections {
+			connInit = dialector.initConnection
+		}
+		connector, err := (&convertingDriver{&duckdb.Driver{}}).newConnector(dialector.DSN, connInit)
+		if err != nil {
+			return fmt.Errorf("failed to open database connection: %w", err)
+		}
+		if dialector.StatementCacheSize > 0 {
+			dialector.stmtGeneration = new(atomic.Uint64)
+			connector.stmtCacheSize = dialector.StatementCacheSize
+			connector.stmtGeneration = dialector.stmtGeneration
+		}
+		db.ConnPool = sql.OpenDB(connector)
 	} else {
 		connPool, err := sql.Open(dialector.DriverName, dialector.DSN)
 		if err != nil {
@@ -501,6 +899,75 @@ func (dialector Dialector) Initialize(db *gorm.DB) error {
 	return nil
 }
 
+// connectionSettings returns Settings merged with the settings of dedicated
+// Config options; explicit Settings entries win
+func (dialector Dialector) connectionSettings() map[string]string {
+	settings := make(map[string]string, len(dialector.Settings)+3)
+	if dialector.CheckpointThreshold != "" {
+		settings["checkpoint_threshold"] = dialector.CheckpointThreshold
+	}
+	if dialector.MemoryLimit != "" {
+		settings["memory_limit"] = dialector.MemoryLimit
+	}
+	if dialector.Threads > 0 {
+		settings["threads"] = strconv.Itoa(dialector.Threads)
+	}
+	for name, value := range dialector.Settings {
+		settings[name] = value
+	}
+	return settings
+}
+
+// memoryLimitPattern matches the sizes DuckDB accepts for memory_limit
+var memoryLimitPattern = regexp.MustCompile(`(?i)^\s*\d+(\.\d+)?\s*(b|bytes?|[kmgt]i?b?|(kilo|mega|giga|tera)bytes?)?\s*$`)
+
+// validateConnectionOptions rejects MemoryLimit, Threads and
+// AutoIncrementStrategy values DuckDB would refuse, before any connection is
+// opened
+func (dialector Dialector) validateConnectionOptions() error {
+	if dialector.Threads < 0 {
+		return fmt.Errorf("invalid Threads %d: must not be negative", dialector.Threads)
+	}
+	if dialector.StatementCacheSize < 0 {
+		return fmt.Errorf("invalid StatementCacheSize %d: must not be negative", dialector.StatementCacheSize)
+	}
+	switch dialector.AutoIncrementStrategy {
+	case StrategySequence:
+	case StrategyIdentity:
+		return ErrIdentityUnsupported
+	default:
+		return fmt.Errorf("invalid AutoIncrementStrategy %d", dialector.AutoIncrementStrategy)
+	}
+	if dialector.MemoryLimit != "" && !memoryLimitPattern.MatchString(dialector.MemoryLimit) {
+		return fmt.Errorf("invalid MemoryLimit %q: expected a size such as \"4GB\" or \"512MiB\"", dialector.MemoryLimit)
+	}
+	return nil
+}
+
+// initConnection applies Settings and then OnConnect to a new connection
+func (dialector Dialector) initConnection(execer driver.ExecerContext) error {
+	settings := dialector.connectionSettings()
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		setting := `SET "` + strings.ReplaceAll(name, `"`, `""`) + `" = ` + sqlStringLiteral(settings[name])
+		if _, err := execer.ExecContext(context.Background(), setting, nil); err != nil {
+			return fmt.Errorf("failed to apply setting %s: %w", name, err)
+		}
+	}
+
+	if dialector.OnConnect != nil {
+		if err := dialector.OnConnect(execer); err != nil {
+			return fmt.Errorf("failed to run OnConnect: %w", err)
+		}
+	}
+	return nil
+}
+
 // Migrator returns a new migrator instance for DuckDB.
 func (dialector Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 	return Migrator{
@@ -520,6 +987,22 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	if field == nil {
 		return ""
 	}
+
+	// precision/scale tags size a DECIMAL column, including for decimal
+	// libraries that bind as strings or floats; an explicit type tag wins
+	if _, explicit := field.TagSettings["TYPE"]; !explicit && field.Precision > 0 {
+		switch strings.ToUpper(string(field.DataType)) {
+		case "", strings.ToUpper(string(schema.Float)), strings.ToUpper(string(schema.String)), "DECIMAL", "NUMERIC":
+			return fmt.Sprintf("DECIMAL(%d,%d)", field.Precision, field.Scale)
+		}
+	}
+
+	// UUID libraries (github.com/google/uuid, go-duckdb's own) bind as text
+	// through their Valuer, which GORM reports as a string field
+	if _, explicit := field.TagSettings["TYPE"]; !explicit && isUUIDType(field.FieldType) {
+		return "UUID"
+	}
+
 	switch field.DataType {
 	case schema.Bool:
 		return "BOOLEAN"
@@ -583,10 +1066,18 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 		case strings.Contains(typeName, "StructType"):
 			return "STRUCT"
 		case strings.Contains(typeName, "MapType"):
-			return "MAP"
+			// A bare MAP is not valid DDL; keep the key/value types from
+			// GormDataType or an explicit type tag
+			if strings.HasPrefix(strings.ToUpper(string(field.DataType)), "MAP(") {
+				return string(field.DataType)
+			}
+			return "MAP(VARCHAR, VARCHAR)"
 		case strings.Contains(typeName, "ListType"):
 			return "LIST"
 		case strings.Contains(typeName, "DecimalType"):
+			if _, explicit := field.TagSettings["TYPE"]; explicit {
+				return string(field.DataType)
+			}
 			return "DECIMAL(18,6)" // Default precision and scale
 		case strings.Contains(typeName, "IntervalType"):
 			return "INTERVAL"
@@ -596,6 +1087,11 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 			return dataTypeJSON
 		// Phase 3A: Core advanced types for 100% DuckDB utilization
 		case strings.Contains(typeName, "ENUMType"):
+			// A bare ENUM is not valid DDL; keep a named enum type from an
+			// explicit type tag
+			if field.DataType != "" && !strings.EqualFold(string(field.DataType), "ENUM") {
+				return string(field.DataType)
+			}
 			return "ENUM" // Will be expanded with enum definition
 		case strings.Contains(typeName, "UNIONType"):
 			return "UNION" // Supports variant data types
@@ -656,6 +1152,11 @@ func (dialector Dialector) DefaultValueOf(field *schema.Field) clause.Expression
 			return clause.Expr{SQL: field.DefaultValue}
 		}
 	}
+	// Database-side defaults (e.g. the nextval sequence behind auto-increment
+	// keys) fill rows that leave the column zero in a multi-row INSERT
+	if field.HasDefaultValue && field.DefaultValueInterface == nil && field.DefaultValue == "" {
+		return clause.Expr{SQL: "DEFAULT"}
+	}
 	return clause.Expr{}
 }
 
@@ -714,8 +1215,9 @@ func (dialector Dialector) QuoteTo(writer clause.Writer, str string) {
 }
 
 // Explain returns an explanation of the SQL query.
+// String values are rendered as single-quoted DuckDB literals.
 func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
-	return logger.ExplainSQL(sql, nil, `"`, vars...)
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
 }
 
 // SavePoint creates a savepoint with the given name.
@@ -869,6 +1371,34 @@ func buildInsertSQL(db *gorm.DB, autoIncrementField *schema.Field) (string, []in
 }
 */
 
+// RowCallbackWorkaroundKey is the session setting that overrides
+// Config.RowCallbackWorkaround for the statements of one session, e.g. to
+// compare both callbacks without reopening the database:
+//
+//	db.Set(duckdb.RowCallbackWorkaroundKey, false).Raw("SELECT 1").Row()
+const RowCallbackWorkaroundKey = "duckdb:row_workaround"
+
+// rowCallback runs rowQueryCallback, or GORM's default RowQuery when the
+// workaround is disabled for the session or the dialector
+func rowCallback(db *gorm.DB) {
+	if rowCallbackWorkaroundEnabled(db) {
+		rowQueryCallback(db)
+		return
+	}
+	callbacks.RowQuery(db)
+}
+
+// rowCallbackWorkaroundEnabled reads RowCallbackWorkaroundKey from the
+// session, falling back to the dialector configuration
+func rowCallbackWorkaroundEnabled(db *gorm.DB) bool {
+	if value, ok := db.Get(RowCallbackWorkaroundKey); ok {
+		if enabled, ok := value.(bool); ok {
+			return enabled
+		}
+	}
+	return shouldApplyRowCallbackFix(db)
+}
+
 // shouldApplyRowCallbackFix determines if we need to apply our RowQuery callback workaround
 // This accounts for future GORM versions that may fix the underlying bug
 func shouldApplyRowCallbackFix(db *gorm.DB) bool {
@@ -906,6 +1436,380 @@ func shouldApplyRowCallbackFix(db *gorm.DB) bool {
 	return true
 }
 
+// registerAfterExecute attaches fn as callback name after each of GORM's
+// executing callbacks, while Statement.SQL and Vars still hold what ran
+func registerAfterExecute(db *gorm.DB, name string, fn func(*gorm.DB)) {
+	errs := []error{
+		db.Callback().Create().After("gorm:create").Register(name, fn),
+		db.Callback().Query().After("gorm:query").Register(name, fn),
+		db.Callback().Update().After("gorm:update").Register(name, fn),
+		db.Callback().Delete().After("gorm:delete").Register(name, fn),
+		db.Callback().Row().After("gorm:row").Register(name, fn),
+		db.Callback().Raw().After("gorm:raw").Register(name, fn),
+	}
+	for _, err := range errs {
+		if err != nil {
+			log.Printf("[WARNING] Failed to register %s callback: %v", name, err)
+		}
+	}
+}
+
+// emptyStringAsNull reports whether db's dialector has EmptyStringAsNull set
+func emptyStringAsNull(db *gorm.DB) bool {
+	dialector, ok := db.Dialector.(*Dialector)
+	return ok && dialector.Config != nil && dialector.EmptyStringAsNull
+}
+
+// emptyStringAsNullClauseBuilder wraps the VALUES or SET clause builder next
+// so empty strings are bound as NULL for nullable string columns
+// (Config.EmptyStringAsNull)
+func emptyStringAsNullClauseBuilder(next clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		if stmt, ok := builder.(*gorm.Statement); ok && stmt.Schema != nil {
+			switch expr := c.Expression.(type) {
+			case clause.Values:
+				values := make([][]interface{}, len(expr.Values))
+				for i, row := range expr.Values {
+					values[i] = make([]interface{}, len(row))
+					for j, value := range row {
+						if j < len(expr.Columns) && isEmptyNullableString(stmt.Schema.LookUpField(expr.Columns[j].Name), value) {
+							value = nil
+						}
+						values[i][j] = value
+					}
+				}
+				expr.Values = values
+				c.Expression = expr
+			case clause.Set:
+				set := make(clause.Set, len(expr))
+				for i, assignment := range expr {
+					if isEmptyNullableString(stmt.Schema.LookUpField(assignment.Column.Name), assignment.Value) {
+						assignment.Value = nil
+					}
+					set[i] = assignment
+				}
+				c.Expression = set
+			}
+		}
+		buildClause(next, c, builder)
+	}
+}
+
+// listValuesClauseBuilder wraps the VALUES or SET clause builder next so Go
+// slice values are written as DuckDB lists; GORM would otherwise expand them
+// into parenthesised placeholder lists
+func listValuesClauseBuilder(next clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		switch expr := c.Expression.(type) {
+		case clause.Values:
+			values := make([][]interface{}, len(expr.Values))
+			for i, row := range expr.Values {
+				values[i] = make([]interface{}, len(row))
+				for j, value := range row {
+					values[i][j] = listValue(value)
+				}
+			}
+			expr.Values = values
+			c.Expression = expr
+		case clause.Set:
+			set := make(clause.Set, len(expr))
+			for i, assignment := range expr {
+				assignment.Value = listValue(assignment.Value)
+				set[i] = assignment
+			}
+			c.Expression = set
+		}
+		buildClause(next, c, builder)
+	}
+}
+
+// buildClause builds c with next, or the clause's own Build when next is nil
+func buildClause(next clause.ClauseBuilder, c clause.Clause, builder clause.Builder) {
+	if next != nil {
+		next(c, builder)
+	} else {
+		c.Build(builder)
+	}
+}
+
+// isEmptyNullableString reports whether value is an empty string written to a
+// nullable string field
+func isEmptyNullableString(field *schema.Field, value interface{}) bool {
+	if field == nil || field.DataType != schema.String || field.NotNull || field.PrimaryKey {
+		return false
+	}
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case *string:
+		return v != nil && *v == ""
+	}
+	return false
+}
+
+// ErrLossyCoercion is returned under Config.StrictTypes when a value would
+// lose information converting to its column's type
+var ErrLossyCoercion = errors.New("duckdb: lossy type coercion")
+
+// integerRanges are the value ranges of DuckDB's fixed-width integer types
+var integerRanges = map[string][2]float64{
+	"TINYINT":   {math.MinInt8, math.MaxInt8},
+	"INT1":      {math.MinInt8, math.MaxInt8},
+	"SMALLINT":  {math.MinInt16, math.MaxInt16},
+	"INT2":      {math.MinInt16, math.MaxInt16},
+	"SHORT":     {math.MinInt16, math.MaxInt16},
+	"INTEGER":   {math.MinInt32, math.MaxInt32},
+	"INT":       {math.MinInt32, math.MaxInt32},
+	"INT4":      {math.MinInt32, math.MaxInt32},
+	"SIGNED":    {math.MinInt32, math.MaxInt32},
+	"BIGINT":    {math.MinInt64, math.MaxInt64},
+	"INT8":      {math.MinInt64, math.MaxInt64},
+	"LONG":      {math.MinInt64, math.MaxInt64},
+	"UTINYINT":  {0, math.MaxUint8},
+	"USMALLINT": {0, math.MaxUint16},
+	"UINTEGER":  {0, math.MaxUint32},
+	"UBIGINT":   {0, math.MaxUint64},
+	"HUGEINT":   {math.Inf(-1), math.Inf(1)},
+	"UHUGEINT":  {0, math.Inf(1)},
+}
+
+// decimalColumnPattern matches a DECIMAL or NUMERIC column type, capturing
+// its scale
+var decimalColumnPattern = regexp.MustCompile(`^(?:DECIMAL|NUMERIC)\s*\(\s*\d+\s*,\s*(\d+)\s*\)$`)
+
+// strictTypes reports whether db's dialector has StrictTypes set
+func strictTypes(db *gorm.DB) bool {
+	dialector, ok := db.Dialector.(*Dialector)
+	return ok && dialector.Config != nil && dialector.StrictTypes
+}
+
+// strictTypesClauseBuilder wraps the VALUES or SET clause builder next (nil
+// for the default) with a check of every value against its column type
+// (Config.StrictTypes)
+func strictTypesClauseBuilder(next clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		if stmt, ok := builder.(*gorm.Statement); ok && stmt.Schema != nil {
+			var err error
+			switch expr := c.Expression.(type) {
+			case clause.Values:
+				for _, row := range expr.Values {
+					for j, value := range row {
+						if j < len(expr.Columns) && err == nil {
+							err = checkCoercion(stmt.Dialector, stmt.Schema.LookUpField(expr.Columns[j].Name), value)
+						}
+					}
+				}
+			case clause.Set:
+				for _, assignment := range expr {
+					if err == nil {
+						err = checkCoercion(stmt.Dialector, stmt.Schema.LookUpField(assignment.Column.Name), assignment.Value)
+					}
+				}
+			}
+			if err != nil {
+				_ = stmt.AddError(err)
+				return
+			}
+		}
+
+		buildClause(next, c, builder)
+	}
+}
+
+// checkCoercion returns an ErrLossyCoercion error when a numeric value would
+// be rounded or overflow converting to field's column type. Other values,
+// and values of fields not in the schema, are left to DuckDB.
+func checkCoercion(dialector gorm.Dialector, field *schema.Field, value interface{}) error {
+	if field == nil {
+		return nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v := reflect.ValueOf(valuer)
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil
+		}
+		var err error
+		if value, err = valuer.Value(); err != nil {
+			return nil // Reported when the statement binds the value
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var number float64
+	isFloat := false
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		number, isFloat = v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number = float64(v.Uint())
+	default:
+		return nil
+	}
+
+	columnType := strings.ToUpper(strings.TrimSpace(dialector.DataTypeOf(field)))
+	if bounds, ok := integerRanges[columnType]; ok {
+		if isFloat && number != math.Trunc(number) {
+			return fmt.Errorf("%w: field %s: %v has a fractional part but column type is %s", ErrLossyCoercion, field.Name, v.Interface(), columnType)
+		}
+		if number < bounds[0] || number > bounds[1] || math.IsNaN(number) {
+			return fmt.Errorf("%w: field %s: %v is out of range for %s", ErrLossyCoercion, field.Name, v.Interface(), columnType)
+		}
+		return nil
+	}
+
+	if match := decimalColumnPattern.FindStringSubmatch(columnType); match != nil && isFloat {
+		scale, _ := strconv.Atoi(match[1])
+		formatted := strconv.FormatFloat(number, 'f', -1, 64)
+		if i := strings.IndexByte(formatted, '.'); i >= 0 && len(formatted)-i-1 > scale {
+			return fmt.Errorf("%w: field %s: %s has more than %d decimal places for %s", ErrLossyCoercion, field.Name, formatted, scale, columnType)
+		}
+	}
+	return nil
+}
+
+// warnOnTimeTruncation reports whether db's dialector has
+// WarnOnTimeTruncation set
+func warnOnTimeTruncation(db *gorm.DB) bool {
+	dialector, ok := db.Dialector.(*Dialector)
+	return ok && dialector.Config != nil && dialector.WarnOnTimeTruncation
+}
+
+// timeTruncationClauseBuilder wraps the VALUES or SET clause builder next
+// with a warning for every time value that loses precision in its column
+// (Config.WarnOnTimeTruncation)
+func timeTruncationClauseBuilder(next clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		if stmt, ok := builder.(*gorm.Statement); ok && stmt.Schema != nil {
+			switch expr := c.Expression.(type) {
+			case clause.Values:
+				for _, row := range expr.Values {
+					for j, value := range row {
+						if j < len(expr.Columns) {
+							warnTimeTruncation(stmt.Dialector, stmt.Schema.LookUpField(expr.Columns[j].Name), value)
+						}
+					}
+				}
+			case clause.Set:
+				for _, assignment := range expr {
+					warnTimeTruncation(stmt.Dialector, stmt.Schema.LookUpField(assignment.Column.Name), assignment.Value)
+				}
+			}
+		}
+
+		buildClause(next, c, builder)
+	}
+}
+
+// warnTimeTruncation logs a warning when value is a time with nanoseconds
+// that field's microsecond-precision column type would drop. Columns typed
+// TIMESTAMP_NS, non-time columns and fields not in the schema are ignored.
+func warnTimeTruncation(dialector gorm.Dialector, field *schema.Field, value interface{}) {
+	if field == nil {
+		return
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v := reflect.ValueOf(valuer)
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return
+		}
+		var err error
+		if value, err = valuer.Value(); err != nil {
+			return
+		}
+	}
+	if t, ok := value.(*time.Time); ok {
+		if t == nil {
+			return
+		}
+		value = *t
+	}
+	t, ok := value.(time.Time)
+	if !ok || t.Nanosecond()%int(time.Microsecond) == 0 {
+		return
+	}
+
+	columnType := strings.ToUpper(strings.TrimSpace(dialector.DataTypeOf(field)))
+	if strings.HasPrefix(columnType, "TIMESTAMP_NS") ||
+		!(strings.HasPrefix(columnType, "TIME") || strings.HasPrefix(columnType, "DATETIME")) {
+		return
+	}
+	log.Printf("[WARNING] field %s: %s has sub-microsecond precision and will be truncated by %s column %s; use type:TIMESTAMP_NS to keep it",
+		field.Name, t.Format(time.RFC3339Nano), columnType, field.DBName)
+}
+
+// logRenderedSQLCallback logs the statement that was just executed with its
+// vars substituted. DryRun statements are skipped as nothing was executed.
+func logRenderedSQLCallback(db *gorm.DB) {
+	if db.DryRun || db.Statement.SQL.Len() == 0 || isSensitiveStatement(db) {
+		return
+	}
+	log.Printf("[GORM-DUCKDB-DEBUG] rendered SQL: %s", db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+}
+
+// sensitiveStatementKey marks a session whose statements carry secrets, such
+// as SetS3Credentials; they are neither logged nor recorded for LastStatement
+const sensitiveStatementKey = "gorm-duckdb:sensitive_statement"
+
+// isSensitiveStatement reports whether db's session set sensitiveStatementKey
+func isSensitiveStatement(db *gorm.DB) bool {
+	sensitive, ok := db.Get(sensitiveStatementKey)
+	return ok && sensitive == true
+}
+
+// lastStatementKey is the instance key recordStatementCallback stores the
+// executed statement under
+const lastStatementKey = "gorm-duckdb:last_statement"
+
+type executedStatement struct {
+	sql  string
+	vars []interface{}
+}
+
+// recordStatementCallback keeps a copy of the statement that was just
+// executed; GORM clears Statement.SQL and Vars once the callbacks finish
+func recordStatementCallback(db *gorm.DB) {
+	if db.Statement.SQL.Len() == 0 || isSensitiveStatement(db) {
+		return
+	}
+	db.InstanceSet(lastStatementKey, executedStatement{
+		sql:  db.Statement.SQL.String(),
+		vars: append([]interface{}(nil), db.Statement.Vars...),
+	})
+}
+
+// LastStatement returns the SQL and bind variables of the statement tx
+// executed, including when it failed:
+//
+//	tx := db.Create(&order)
+//	if tx.Error != nil {
+//		query, vars := duckdb.LastStatement(tx)
+//		log.Printf("%v: %s", tx.Error, tx.Dialector.Explain(query, vars...))
+//	}
+//
+// Pass the *gorm.DB returned by the finisher (Create, Find, Update, ...);
+// the DB it was called on keeps its own Statement. For a DryRun it returns
+// the SQL that was built. Both are empty if nothing was built.
+func LastStatement(tx *gorm.DB) (sql string, vars []interface{}) {
+	if tx == nil || tx.Statement == nil {
+		return "", nil
+	}
+	if recorded, ok := tx.InstanceGet(lastStatementKey); ok {
+		if stmt, ok := recorded.(executedStatement); ok {
+			return stmt.sql, append([]interface{}(nil), stmt.vars...)
+		}
+	}
+	return tx.Statement.SQL.String(), append([]interface{}(nil), tx.Statement.Vars...)
+}
+
 // rowQueryCallback replaces GORM's default row query callback with a DuckDB-compatible version
 //
 // BACKGROUND: This is a workaround for a critical bug in GORM's RowQuery callback implementation
@@ -928,8 +1832,9 @@ func rowQueryCallback(db *gorm.DB) {
 		return
 	}
 
-	// Only process if we have SQL to execute
-	if db.Statement.SQL.Len() == 0 {
+	// Build SQL for chained queries (Table/Select/Where...); Raw already set it
+	callbacks.BuildQuerySQL(db)
+	if db.Error != nil || db.Statement.SQL.Len() == 0 {
 		return
 	}
 
@@ -954,6 +1859,19 @@ func rowQueryCallback(db *gorm.DB) {
 	db.RowsAffected = -1
 }
 
+// defaultCallbackConfig lists the clauses DuckDB supports for GORM's stock
+// create/query/update/delete callbacks
+var defaultCallbackConfig = &callbacks.Config{
+	CreateClauses: []string{"INSERT", "VALUES", "ON CONFLICT", "RETURNING"},
+	QueryClauses:  queryClauses,
+	UpdateClauses: []string{"UPDATE", "SET", "FROM", "WHERE", "RETURNING"},
+	DeleteClauses: []string{"DELETE", "FROM", "WHERE", "RETURNING"},
+}
+
+// gormCreateCallback is GORM's stock create, used for statements the
+// single-row DuckDB create callback does not handle
+var gormCreateCallback = callbacks.Create(defaultCallbackConfig)
+
 // duckdbCreateCallback implements a custom CREATE callback to work around
 // GORM v1.31.1 issue where gorm:create doesn't generate INSERT SQL for DuckDB dialector
 //nolint:gosec // G115: Integer conversions in ID handling are validated by GORM
@@ -968,6 +1886,17 @@ func duckdbCreateCallback(db *gorm.DB) {
 		return
 	}
 
+	if onConflict, ok := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict); ok && len(onConflict.TargetWhere.Exprs) > 0 {
+		db.Error = ErrConflictTargetWhere
+		return
+	}
+
+	if needsGormCreate(stmt) {
+		debugLog("duckdbCreateCallback: delegating to gorm:create")
+		gormCreateCallback(db)
+		return
+	}
+
 	debugLog("duckdbCreateCallback called")
 	debugLog("duckdbCreateCallback: building INSERT for table %s", stmt.Table)
 
@@ -975,15 +1904,21 @@ func duckdbCreateCallback(db *gorm.DB) {
 	var columns []string
 	var placeholders []string
 	var values []interface{}
-	var autoIncrementField *schema.Field
+	var autoIncrementFields []*schema.Field
+	var sequenceKeyField *schema.Field
 
-	// Find auto-increment field and collect values
+	// Find auto-increment fields left zero and collect values
 	for _, field := range stmt.Schema.Fields {
-		if field.AutoIncrement {
-			autoIncrementField = field
-			debugLog("duckdbCreateCallback: skipping auto-increment field %s", field.Name)
+		if field.DBName == "" || !field.Creatable {
 			continue
 		}
+		if field.AutoIncrement {
+			if _, isZero := field.ValueOf(stmt.Context, stmt.ReflectValue); isZero {
+				autoIncrementFields = append(autoIncrementFields, field)
+				debugLog("duckdbCreateCallback: skipping auto-increment field %s", field.Name)
+				continue
+			}
+		}
 
 		// Get field value from the model
 		fieldValue := stmt.ReflectValue
@@ -994,9 +1929,30 @@ func duckdbCreateCallback(db *gorm.DB) {
 		if fieldValue.Kind() == reflect.Struct {
 			modelFieldValue := fieldValue.FieldByName(field.Name)
 			if modelFieldValue.IsValid() {
+				if isSequenceDefaultedKey(field) && modelFieldValue.IsZero() {
+					// Leave the column to its database default and read the
+					// key back from the sequence after the insert
+					sequenceKeyField = field
+					debugLog("duckdbCreateCallback: leaving field %s to its default", field.Name)
+					continue
+				}
 				columns = append(columns, fmt.Sprintf(`"%s"`, field.DBName))
-				placeholders = append(placeholders, "?")
-				values = append(values, modelFieldValue.Interface())
+				if emptyStringAsNull(db) && isEmptyNullableString(field, modelFieldValue.Interface()) {
+					placeholders = append(placeholders, "NULL")
+					continue
+				}
+				if strictTypes(db) {
+					if err := checkCoercion(db.Dialector, field, modelFieldValue.Interface()); err != nil {
+						db.Error = err
+						return
+					}
+				}
+				if warnOnTimeTruncation(db) {
+					warnTimeTruncation(db.Dialector, field, modelFieldValue.Interface())
+				}
+				placeholder, fieldVars := createValueBinding(db, modelFieldValue)
+				placeholders = append(placeholders, placeholder)
+				values = append(values, fieldVars...)
 				debugLog("duckdbCreateCallback: adding field %s = %v", field.DBName, modelFieldValue.Interface())
 			}
 		}
@@ -1013,7 +1969,12 @@ func duckdbCreateCallback(db *gorm.DB) {
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "))
 
-	// Add RETURNING clause for auto-increment fields
+	// Add RETURNING clause for a single generated key. A composite key whose
+	// columns are all set, or several generated columns, insert without it.
+	var autoIncrementField *schema.Field
+	if len(autoIncrementFields) == 1 {
+		autoIncrementField = autoIncrementFields[0]
+	}
 	hasAutoIncrement := autoIncrementField != nil
 	if hasAutoIncrement {
 		sql += fmt.Sprintf(` RETURNING "%s"`, autoIncrementField.DBName)
@@ -1023,6 +1984,11 @@ func duckdbCreateCallback(db *gorm.DB) {
 	debugLog("duckdbCreateCallback: generated SQL: %s", sql)
 	debugLog("duckdbCreateCallback: vars: %+v", any(values))
 
+	// Record the statement so GORM's logger and after-callbacks see what ran
+	stmt.SQL.Reset()
+	stmt.SQL.WriteString(sql)
+	stmt.Vars = values
+
 	// Execute the query
 	if hasAutoIncrement {
 		// Use QueryRow for RETURNING
@@ -1034,6 +2000,10 @@ func duckdbCreateCallback(db *gorm.DB) {
 		} else {
 			db.RowsAffected = 1
 			debugLog("duckdbCreateCallback: QueryRow succeeded, ID: %v", id)
+			if id == nil {
+				setKeyFromSequence(db, autoIncrementField)
+				return
+			}
 			
 			// Set the ID back to the model
 			// Get the struct value (dereference pointer if needed)
@@ -1098,10 +2068,117 @@ func duckdbCreateCallback(db *gorm.DB) {
 			affected, _ := result.RowsAffected()
 			db.RowsAffected = affected
 			debugLog("duckdbCreateCallback: Exec succeeded, rows affected: %d", affected)
+			if sequenceKeyField != nil && affected == 1 {
+				setKeyFromSequence(db, sequenceKeyField)
+			}
 		}
 	}
 }
 
+// isSequenceDefaultedKey reports whether field is a primary key, other than
+// the auto-increment one, whose database default (e.g.
+// default:nextval('seq_orders_id')) generates it
+func isSequenceDefaultedKey(field *schema.Field) bool {
+	return field.PrimaryKey && !field.AutoIncrement && field.HasDefaultValue &&
+		field.DefaultValueInterface == nil && strings.HasPrefix(strings.ToLower(field.DefaultValue), "nextval(")
+}
+
+// setKeyFromSequence populates field after a single-row insert that did not
+// return it, from currval of the seq_<table>_<column> sequence the migrator
+// names. It does nothing, logging at debug level, unless the column's default
+// draws from that sequence: currval of another or a missing sequence would
+// fail, and abort the transaction the insert runs in.
+func setKeyFromSequence(db *gorm.DB, field *schema.Field) {
+	stmt := db.Statement
+	table := stmt.Table
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		table = table[i+1:]
+	}
+	sequenceName := "seq_" + strings.ToLower(table) + "_" + strings.ToLower(field.DBName)
+
+	var columnDefault sql.NullString
+	err := stmt.ConnPool.QueryRowContext(stmt.Context,
+		"SELECT column_default FROM duckdb_columns() WHERE database_name = current_database() AND table_name = ? AND column_name = ?",
+		table, field.DBName).Scan(&columnDefault)
+	if err != nil || !strings.Contains(columnDefault.String, "'"+sequenceName+"'") {
+		debugLog("setKeyFromSequence: column %s.%s does not default to sequence %s (default %q, err %v)",
+			table, field.DBName, sequenceName, columnDefault.String, err)
+		return
+	}
+
+	var id int64
+	if err := stmt.ConnPool.QueryRowContext(stmt.Context, "SELECT currval("+sqlStringLiteral(sequenceName)+")").Scan(&id); err != nil {
+		debugLog("setKeyFromSequence: currval(%s) failed: %v", sequenceName, err)
+		return
+	}
+	if err := field.Set(stmt.Context, stmt.ReflectValue, id); err != nil {
+		debugLog("setKeyFromSequence: failed to set %s to %d: %v", field.Name, id, err)
+		return
+	}
+	debugLog("setKeyFromSequence: set %s to %d from %s", field.Name, id, sequenceName)
+}
+
+// ErrConflictTargetWhere is returned for an OnConflict with TargetWhere.
+// DuckDB has no partial indexes, so ON CONFLICT (col) WHERE ... cannot name
+// one; filter the update with OnConflict.Where (DO UPDATE SET ... WHERE ...)
+// instead.
+var ErrConflictTargetWhere = errors.New("duckdb: ON CONFLICT target WHERE is not supported; use OnConflict.Where to filter DO UPDATE")
+
+// needsGormCreate reports whether stmt needs GORM's stock create: batches
+// (slices, e.g. association saves), map values, ON CONFLICT and RETURNING
+// clauses and Select/Omit column lists are not handled by
+// duckdbCreateCallback. GORM
+// inserts a batch of structs or struct pointers as one multi-row
+// INSERT ... RETURNING, so RowsAffected is the number of rows inserted and
+// each element gets its auto-increment key back.
+func needsGormCreate(stmt *gorm.Statement) bool {
+	if _, ok := stmt.Clauses["ON CONFLICT"]; ok {
+		return true
+	}
+	if _, ok := stmt.Clauses["RETURNING"]; ok {
+		return true
+	}
+	if len(stmt.Selects) > 0 || len(stmt.Omits) > 0 {
+		return true
+	}
+
+	reflectValue := stmt.ReflectValue
+	for reflectValue.Kind() == reflect.Ptr {
+		reflectValue = reflectValue.Elem()
+	}
+	return reflectValue.Kind() != reflect.Struct
+}
+
+// createValueBinding returns the VALUES placeholder and bind variables for a
+// field. gorm.Valuer fields (e.g. MapType), plain Go maps and slices are
+// expanded into SQL expressions, as go-duckdb cannot bind every one of them
+// as a single parameter.
+func createValueBinding(db *gorm.DB, fieldValue reflect.Value) (string, []interface{}) {
+	value := fieldValue.Interface()
+
+	var expr clause.Expr
+	switch v := value.(type) {
+	case gorm.Valuer:
+		expr = v.GormValue(db.Statement.Context, db)
+	case driver.Valuer:
+		return "?", []interface{}{value}
+	default:
+		switch {
+		case fieldValue.Kind() == reflect.Map:
+			expr = mapLiteralExpr(fieldValue)
+		case isSlice(value):
+			expr = listLiteralExpr(fieldValue)
+		default:
+			return "?", []interface{}{value}
+		}
+	}
+
+	// Render through a scratch statement so nested expressions are expanded
+	binding := &gorm.Statement{DB: db, Context: db.Statement.Context}
+	binding.AddVar(binding, expr)
+	return binding.SQL.String(), binding.Vars
+}
+
 // duckdbQueryCallback implements a custom QUERY callback to work around
 // GORM v1.31.1 issue where gorm:query doesn't generate SELECT SQL for DuckDB dialector
 func duckdbQueryCallback(db *gorm.DB) {
@@ -1112,10 +2189,11 @@ func duckdbQueryCallback(db *gorm.DB) {
 		return
 	}
 
-	// Try GORM's standard build first
+	// Try GORM's standard build first; BuildQuerySQL turns Select/Joins/Preload
+	// state into clauses before building, so explicit column lists are honoured
 	if db.Statement.SQL.String() == "" {
-		debugLog("duckdbQueryCallback: trying GORM's standard Build()")
-		db.Statement.Build("SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR")
+		debugLog("duckdbQueryCallback: trying GORM's BuildQuerySQL()")
+		callbacks.BuildQuerySQL(db)
 	}
 
 	// If GORM's build failed or produced incomplete SQL, build manually
@@ -1176,6 +2254,11 @@ func duckdbQueryCallback(db *gorm.DB) {
 		return
 	}
 
+	// DryRun sessions (including subqueries embedded via AddVar) only need the SQL
+	if db.DryRun {
+		return
+	}
+
 	if rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, db.Statement.SQL.String(), db.Statement.Vars...); err != nil {
 		debugLog("duckdbQueryCallback: query failed: %v", err)
 		if err := db.AddError(err); err != nil {
@@ -1188,16 +2271,95 @@ func duckdbQueryCallback(db *gorm.DB) {
 				debugLog("duckdbQueryCallback: failed to close rows: %v", err)
 			}
 		}()
-		gorm.Scan(rows, db, 0)
+		gorm.Scan(&listRows{Rows: rows}, db, 0)
 	}
 }
 
+// Errors translateDriverError attaches to driver errors, so callers can test
+// for them with errors.Is while the message stays DuckDB's own. Constraint
+// violations are tagged with gorm.ErrDuplicatedKey and
+// gorm.ErrForeignKeyViolated.
+var (
+	// ErrFileNotFound is returned when a file read by COPY or a table
+	// function such as read_parquet does not exist
+	ErrFileNotFound = errors.New("duckdb: file not found")
+
+	// ErrSchemaMismatch is returned when the columns or types of the data
+	// being loaded do not fit the target table
+	ErrSchemaMismatch = errors.New("duckdb: schema mismatch")
+
+	// ErrNotNullViolation is returned when a row leaves a NOT NULL column
+	// NULL
+	ErrNotNullViolation = errors.New("duckdb: NOT NULL constraint violated")
+
+	// ErrCheckViolation is returned when a row fails a CHECK constraint. It
+	// also matches gorm.ErrCheckConstraintViolated.
+	ErrCheckViolation = fmt.Errorf("duckdb: CHECK constraint violated: %w", gorm.ErrCheckConstraintViolated)
+)
+
+// driverErrorPatterns maps substrings of DuckDB error messages to the
+// sentinel attached to them
+var driverErrorPatterns = []struct {
+	pattern  string
+	sentinel error
+}{
+	{"No files found that match the pattern", ErrFileNotFound},
+	{"No such file or directory", ErrFileNotFound},
+	{"does not have a column with name", ErrSchemaMismatch},
+	{"values were supplied", ErrSchemaMismatch},
+	{"Unimplemented type for cast", ErrSchemaMismatch},
+	{"Duplicate key", gorm.ErrDuplicatedKey},
+	{"violates unique constraint", gorm.ErrDuplicatedKey},
+	{"violates primary key constraint", gorm.ErrDuplicatedKey},
+	{"PRIMARY KEY or UNIQUE constraint", gorm.ErrDuplicatedKey},
+	{"violates foreign key constraint", gorm.ErrForeignKeyViolated},
+	{"NOT NULL constraint failed", ErrNotNullViolation},
+	{"Constraint Error: NOT NULL", ErrNotNullViolation},
+	{"CHECK constraint failed", ErrCheckViolation},
+}
+
+// classifiedError is a driver error tagged with a sentinel; it reads as the
+// driver error and matches both with errors.Is
+type classifiedError struct {
+	sentinel error
+	err      error
+}
+
+func (e classifiedError) Error() string   { return e.err.Error() }
+func (e classifiedError) Unwrap() []error { return []error{e.sentinel, e.err} }
+
 // translateDriverError provides production-ready error translation for DuckDB driver errors
 func translateDriverError(err error) error {
-	// TODO: Add more robust error translation for DuckDB-specific errors
-	// For now, just wrap with context
 	if err == nil {
 		return nil
 	}
-	return fmt.Errorf("duckdb driver error: %w", err)
+	return fmt.Errorf("duckdb driver error: %w", classifyDriverError(err))
+}
+
+// contextDriverError translates err like translateDriverError, and makes it
+// match ctx's error when ctx ended while the statement ran. go-duckdb
+// interrupts a running query once its context is done; depending on where
+// the interrupt lands, DuckDB reports it as its own "Interrupted" error
+// rather than context.Canceled or context.DeadlineExceeded.
+func contextDriverError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("%w: %w", ctxErr, translateDriverError(err))
+	}
+	return translateDriverError(err)
+}
+
+// classifyDriverError tags err with the sentinel of the first matching
+// driverErrorPatterns entry. Errors already carrying that sentinel, e.g. ones
+// translateDriverError returned, are left as they are.
+func classifyDriverError(err error) error {
+	message := strings.ToLower(err.Error())
+	for _, p := range driverErrorPatterns {
+		if strings.Contains(message, strings.ToLower(p.pattern)) {
+			if errors.Is(err, p.sentinel) {
+				return err
+			}
+			return classifiedError{sentinel: p.sentinel, err: err}
+		}
+	}
+	return err
 }
@@ -102,7 +102,7 @@ func TestConvertNamedValues(t *testing.T) {
 			},
 		},
 		{
-			name: "slice_conversion",
+			name: "slice_passthrough",
 			input: []driver.NamedValue{
 				{Ordinal: 1, Value: []int{1, 2, 3}},
 				{Ordinal: 2, Value: []string{"hello", "world"}},
@@ -111,8 +111,12 @@ func TestConvertNamedValues(t *testing.T) {
 				if len(result) != 2 {
 					return false
 				}
-				// Should convert slice to string format
-				return result[0].Value == "[1, 2, 3]" && result[1].Value == "['hello', 'world']"
+				// Slices are bound as lists through a driver.Valuer
+				ints, err1 := result[0].Value.(driver.Valuer).Value()
+				strs, err2 := result[1].Value.(driver.Valuer).Value()
+				return err1 == nil && err2 == nil &&
+					reflect.DeepEqual(ints, []int{1, 2, 3}) &&
+					reflect.DeepEqual(strs, []string{"hello", "world"})
 			},
 		},
 		{
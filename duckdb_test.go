@@ -1,13 +1,21 @@
 package duckdb_test
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
@@ -122,6 +130,261 @@ func TestTransaction(t *testing.T) {
 	assert.Equal(t, int64(2), count)
 }
 
+func TestBatchCreate(t *testing.T) {
+	db := setupTestDB(t)
+
+	t.Run("SliceOfStructs", func(t *testing.T) {
+		users := []User{
+			{Name: "Ann", Email: "ann@example.com"},
+			{Name: "Bob", Email: "bob@example.com"},
+			{Name: "Cid", Email: "cid@example.com"},
+		}
+		result := db.Create(&users)
+		require.NoError(t, result.Error)
+		assert.Equal(t, int64(3), result.RowsAffected)
+		for _, user := range users {
+			require.NotZero(t, user.ID)
+			var stored User
+			require.NoError(t, db.First(&stored, user.ID).Error)
+			assert.Equal(t, user.Email, stored.Email)
+		}
+		assert.NotEqual(t, users[0].ID, users[1].ID)
+	})
+
+	t.Run("SliceOfPointers", func(t *testing.T) {
+		users := []*User{
+			{Name: "Dee", Email: "dee@example.com"},
+			{Name: "Eve", Email: "eve@example.com"},
+		}
+		result := db.Create(users)
+		require.NoError(t, result.Error)
+		assert.Equal(t, int64(2), result.RowsAffected)
+		for _, user := range users {
+			require.NotZero(t, user.ID)
+			var stored User
+			require.NoError(t, db.First(&stored, user.ID).Error)
+			assert.Equal(t, user.Name, stored.Name)
+		}
+	})
+
+	t.Run("CreateInBatches", func(t *testing.T) {
+		users := make([]User, 5)
+		for i := range users {
+			users[i] = User{Name: fmt.Sprintf("Batch %d", i), Email: fmt.Sprintf("batch%d@example.com", i)}
+		}
+		result := db.CreateInBatches(&users, 2)
+		require.NoError(t, result.Error)
+		assert.Equal(t, int64(5), result.RowsAffected)
+		for _, user := range users {
+			assert.NotZero(t, user.ID)
+		}
+	})
+
+	var count int64
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(10), count)
+}
+
+func TestFirstOrCreate(t *testing.T) {
+	db := setupTestDB(t)
+
+	// First call creates the row from the conditions plus Attrs
+	var first User
+	result := db.Where(User{Email: "carol@example.com"}).Attrs(User{Name: "Carol", Age: 41}).FirstOrCreate(&first)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	assert.NotZero(t, first.ID)
+
+	// Second call finds the existing row and ignores Attrs
+	var second User
+	result = db.Where(User{Email: "carol@example.com"}).Attrs(User{Name: "Other", Age: 99}).FirstOrCreate(&second)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(0), result.RowsAffected)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, "Carol", second.Name)
+	assert.Equal(t, uint8(41), second.Age)
+
+	var count int64
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Assign updates the existing row
+	var assigned User
+	require.NoError(t, db.Where(User{Email: "carol@example.com"}).Assign(User{Age: 42}).FirstOrCreate(&assigned).Error)
+	assert.Equal(t, first.ID, assigned.ID)
+
+	var stored User
+	require.NoError(t, db.First(&stored, first.ID).Error)
+	assert.Equal(t, uint8(42), stored.Age)
+
+	// FirstOrInit does not persist a missing row
+	var initialized User
+	require.NoError(t, db.FirstOrInit(&initialized, User{Email: "dave@example.com"}).Error)
+	assert.Zero(t, initialized.ID)
+	assert.Equal(t, "dave@example.com", initialized.Email)
+
+	require.NoError(t, db.Model(&User{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+type AssocPost struct {
+	ID    uint `gorm:"primaryKey"`
+	Title string
+	Tags  []AssocTag `gorm:"many2many:assoc_post_tags;"`
+}
+
+type AssocTag struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func TestMany2ManyAssociation(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&AssocPost{}, &AssocTag{}))
+
+	joinedTagIDs := func(postID uint) []uint {
+		var ids []uint
+		require.NoError(t, db.Table("assoc_post_tags").Where("assoc_post_id = ?", postID).
+			Order("assoc_tag_id").Pluck("assoc_tag_id", &ids).Error)
+		return ids
+	}
+
+	post := AssocPost{Title: "DuckDB tips", Tags: []AssocTag{{Name: "go"}}}
+	require.NoError(t, db.Create(&post).Error)
+	require.NotZero(t, post.Tags[0].ID)
+	goTag := post.Tags[0]
+
+	olap := AssocTag{Name: "olap"}
+	require.NoError(t, db.Model(&post).Association("Tags").Append(&olap))
+	assert.Equal(t, []uint{goTag.ID, olap.ID}, joinedTagIDs(post.ID))
+
+	// Replace swaps the whole set in the join table
+	replacement := []AssocTag{olap, {Name: "sql"}}
+	require.NoError(t, db.Model(&post).Association("Tags").Replace(replacement))
+	sqlTag := replacement[1]
+	require.NotZero(t, sqlTag.ID)
+	assert.Equal(t, []uint{olap.ID, sqlTag.ID}, joinedTagIDs(post.ID))
+
+	var loaded AssocPost
+	require.NoError(t, db.Preload("Tags").First(&loaded, post.ID).Error)
+	require.Len(t, loaded.Tags, 2)
+	assert.ElementsMatch(t, []string{"olap", "sql"}, []string{loaded.Tags[0].Name, loaded.Tags[1].Name})
+	assert.Equal(t, int64(2), db.Model(&post).Association("Tags").Count())
+
+	// Delete removes only the join rows, not the tags themselves
+	require.NoError(t, db.Model(&post).Association("Tags").Delete(&olap))
+	assert.Equal(t, []uint{sqlTag.ID}, joinedTagIDs(post.ID))
+
+	require.NoError(t, db.Model(&post).Association("Tags").Clear())
+	assert.Empty(t, joinedTagIDs(post.ID))
+
+	var tagCount int64
+	require.NoError(t, db.Model(&AssocTag{}).Count(&tagCount).Error)
+	assert.Equal(t, int64(3), tagCount)
+}
+
+type PreloadAuthor struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Posts []PreloadPost `gorm:"foreignKey:AuthorID"`
+}
+
+type PreloadPost struct {
+	ID        uint `gorm:"primaryKey"`
+	AuthorID  uint
+	Title     string
+	Published bool
+}
+
+func TestPreloadWithConditions(t *testing.T) {
+	dialectors := map[string]gorm.Dialector{
+		"RowCallbackWorkaround": duckdb.Open(":memory:"),
+		"StockRowCallback":      duckdb.OpenWithRowCallbackWorkaround(":memory:", false),
+	}
+
+	for name, dialector := range dialectors {
+		t.Run(name, func(t *testing.T) {
+			db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			require.NoError(t, err)
+			require.NoError(t, db.AutoMigrate(&PreloadAuthor{}, &PreloadPost{}))
+
+			require.NoError(t, db.Create(&PreloadAuthor{Name: "ann", Posts: []PreloadPost{
+				{Title: "draft", Published: false},
+				{Title: "live", Published: true},
+				{Title: "launch", Published: true},
+			}}).Error)
+			require.NoError(t, db.Create(&PreloadAuthor{Name: "bob", Posts: []PreloadPost{
+				{Title: "notes", Published: false},
+			}}).Error)
+
+			var authors []PreloadAuthor
+			require.NoError(t, db.Preload("Posts", "published = ?", true).Order("id").Find(&authors).Error)
+			require.Len(t, authors, 2)
+			require.Len(t, authors[0].Posts, 2)
+			for _, post := range authors[0].Posts {
+				assert.True(t, post.Published)
+				assert.Equal(t, authors[0].ID, post.AuthorID)
+			}
+			assert.Empty(t, authors[1].Posts)
+
+			// Function conditions can filter and order the preloaded children
+			authors = nil
+			require.NoError(t, db.Preload("Posts", func(tx *gorm.DB) *gorm.DB {
+				return tx.Where("published = ?", true).Order("title")
+			}).Where("name = ?", "ann").Find(&authors).Error)
+			require.Len(t, authors, 1)
+			require.Len(t, authors[0].Posts, 2)
+			assert.Equal(t, "launch", authors[0].Posts[0].Title)
+			assert.Equal(t, "live", authors[0].Posts[1].Title)
+		})
+	}
+}
+
+type SoftAccount struct {
+	ID        uint   `gorm:"primaryKey"`
+	Email     string `gorm:"unique"`
+	Name      string
+	DeletedAt gorm.DeletedAt
+}
+
+func TestOnConflictWhere(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&SoftAccount{}))
+
+	require.NoError(t, db.Create(&SoftAccount{Email: "live@example.com", Name: "old"}).Error)
+	deleted := SoftAccount{Email: "gone@example.com", Name: "old"}
+	require.NoError(t, db.Create(&deleted).Error)
+	require.NoError(t, db.Delete(&deleted).Error)
+
+	// Only update rows that are not soft-deleted
+	upsert := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "email"}},
+		Where:     clause.Where{Exprs: []clause.Expression{gorm.Expr("soft_accounts.deleted_at IS NULL")}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}
+	require.NoError(t, db.Clauses(upsert).Create(&SoftAccount{Email: "live@example.com", Name: "new"}).Error)
+	require.NoError(t, db.Clauses(upsert).Create(&SoftAccount{Email: "gone@example.com", Name: "new"}).Error)
+
+	var live, gone SoftAccount
+	require.NoError(t, db.Where("email = ?", "live@example.com").First(&live).Error)
+	assert.Equal(t, "new", live.Name)
+	require.NoError(t, db.Unscoped().Where("email = ?", "gone@example.com").First(&gone).Error)
+	assert.Equal(t, "old", gone.Name)
+
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&SoftAccount{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	t.Run("TargetWhereUnsupported", func(t *testing.T) {
+		err := db.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "email"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{gorm.Expr("deleted_at IS NULL")}},
+			DoUpdates:   clause.AssignmentColumns([]string{"name"}),
+		}).Create(&SoftAccount{Email: "live@example.com", Name: "other"}).Error
+		assert.ErrorIs(t, err, duckdb.ErrConflictTargetWhere)
+	})
+}
+
 func TestErrorTranslator(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -162,3 +425,824 @@ func TestDataTypes(t *testing.T) {
 	// Check that timestamps are approximately equal (within a second)
 	assert.WithinDuration(t, user.Birthday, retrieved.Birthday, time.Second)
 }
+
+func TestLogRenderedSQL(t *testing.T) {
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(originalOutput)
+
+	dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{LogRenderedSQL: true})
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&User{}))
+
+	user := User{Name: "O'Brien", Email: "obrien@example.com", Age: 42}
+	require.NoError(t, db.Create(&user).Error)
+
+	var found User
+	require.NoError(t, db.Where("name = ? AND age = ?", "O'Brien", 42).First(&found).Error)
+
+	output := buf.String()
+	assert.Contains(t, output, "rendered SQL: INSERT INTO")
+	assert.Contains(t, output, "'obrien@example.com'")
+	assert.Contains(t, output, "name = 'O''Brien' AND age = 42")
+	assert.NotContains(t, output, "name = ? AND age = ?")
+}
+
+type PreciseEvent struct {
+	ID         uint `gorm:"primaryKey"`
+	Name       string
+	OccurredAt time.Time
+	RecordedAt *time.Time `gorm:"type:TIMESTAMP_NS"` // the zero time is out of TIMESTAMP_NS range
+}
+
+func TestWarnOnTimeTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(originalOutput)
+
+	dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{WarnOnTimeTruncation: true})
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&PreciseEvent{}))
+
+	precise := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+	whole := time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC)
+
+	buf.Reset()
+	event := PreciseEvent{Name: "tick", OccurredAt: whole, RecordedAt: &precise}
+	require.NoError(t, db.Create(&event).Error)
+	assert.NotContains(t, buf.String(), "sub-microsecond", "microsecond values and TIMESTAMP_NS columns must not warn")
+
+	buf.Reset()
+	require.NoError(t, db.Create(&PreciseEvent{Name: "tock", OccurredAt: precise}).Error)
+	assert.Contains(t, buf.String(), "field OccurredAt")
+	assert.Contains(t, buf.String(), "sub-microsecond")
+
+	buf.Reset()
+	require.NoError(t, db.Create([]PreciseEvent{{Name: "a", OccurredAt: precise}, {Name: "b", OccurredAt: whole}}).Error)
+	assert.Contains(t, buf.String(), "field OccurredAt")
+
+	buf.Reset()
+	require.NoError(t, db.Model(&event).Update("occurred_at", precise).Error)
+	assert.Contains(t, buf.String(), "field OccurredAt")
+
+	var found PreciseEvent
+	require.NoError(t, db.First(&found, event.ID).Error)
+	assert.Equal(t, whole, found.OccurredAt.UTC(), "DuckDB keeps microseconds only")
+	require.NotNil(t, found.RecordedAt)
+	assert.Equal(t, precise, found.RecordedAt.UTC(), "TIMESTAMP_NS keeps nanoseconds")
+}
+
+type LegacyTicket struct {
+	Code  uint `gorm:"primaryKey;autoIncrement:false;default:nextval('seq_legacy_tickets_code')"`
+	Title string
+}
+
+type LegacyPass struct {
+	Code  uint `gorm:"primaryKey;autoIncrement:false;default:nextval('pass_numbers')"`
+	Title string
+}
+
+func TestSequenceKeyFallback(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE SEQUENCE seq_legacy_tickets_code START 100").Error)
+	require.NoError(t, db.Exec("CREATE TABLE legacy_tickets (code BIGINT DEFAULT nextval('seq_legacy_tickets_code') PRIMARY KEY, title VARCHAR)").Error)
+
+	first := LegacyTicket{Title: "first"}
+	require.NoError(t, db.Create(&first).Error)
+	assert.Equal(t, uint(100), first.Code)
+
+	second := LegacyTicket{Title: "second"}
+	require.NoError(t, db.Create(&second).Error)
+	assert.Equal(t, uint(101), second.Code)
+
+	explicit := LegacyTicket{Code: 7, Title: "explicit"}
+	require.NoError(t, db.Create(&explicit).Error)
+	assert.Equal(t, uint(7), explicit.Code)
+
+	var found LegacyTicket
+	require.NoError(t, db.First(&found, "title = ?", "second").Error)
+	assert.Equal(t, second.Code, found.Code)
+
+	t.Run("OtherSequence", func(t *testing.T) {
+		// The key comes from a sequence not named seq_<table>_<column>, so
+		// it is left unset rather than failing the insert
+		require.NoError(t, db.Exec("CREATE SEQUENCE pass_numbers START 1").Error)
+		require.NoError(t, db.Exec("CREATE TABLE legacy_passes (code BIGINT DEFAULT nextval('pass_numbers') PRIMARY KEY, title VARCHAR)").Error)
+
+		pass := LegacyPass{Title: "guest"}
+		require.NoError(t, db.Create(&pass).Error)
+		assert.Zero(t, pass.Code)
+
+		var stored LegacyPass
+		require.NoError(t, db.First(&stored, "title = ?", "guest").Error)
+		assert.Equal(t, uint(1), stored.Code)
+	})
+}
+
+func TestLastStatement(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&User{}))
+
+	user := User{Name: "Ada", Email: "ada@example.com", Age: 36}
+	tx := db.Create(&user)
+	require.NoError(t, tx.Error)
+
+	query, vars := duckdb.LastStatement(tx)
+	assert.Contains(t, query, `INSERT INTO "users"`)
+	assert.Contains(t, query, "RETURNING")
+	assert.Contains(t, vars, "ada@example.com")
+	assert.Contains(t, vars, uint8(36))
+
+	tx = db.Model(&User{}).Where("age > ?", 30).Update("name", "Ada L.")
+	require.NoError(t, tx.Error)
+	query, vars = duckdb.LastStatement(tx)
+	assert.Contains(t, query, `UPDATE "users" SET "name"=?`)
+	assert.Contains(t, vars, "Ada L.")
+	assert.Contains(t, vars, 30)
+
+	var found []User
+	tx = db.Where("email = ?", "ada@example.com").Find(&found)
+	require.NoError(t, tx.Error)
+	query, vars = duckdb.LastStatement(tx)
+	assert.Contains(t, query, `SELECT * FROM "users" WHERE email = ?`)
+	assert.Equal(t, []interface{}{"ada@example.com"}, vars)
+
+	t.Run("Failed", func(t *testing.T) {
+		tx := db.Where("missing_column = ?", 1).Find(&found)
+		require.Error(t, tx.Error)
+		query, vars := duckdb.LastStatement(tx)
+		assert.Contains(t, query, "missing_column = ?")
+		assert.Equal(t, []interface{}{1}, vars)
+	})
+
+	t.Run("Unexecuted", func(t *testing.T) {
+		query, vars := duckdb.LastStatement(db)
+		assert.Empty(t, query)
+		assert.Empty(t, vars)
+	})
+}
+
+type SparseContact struct {
+	ID       uint   `gorm:"primaryKey"`
+	Name     string `gorm:"not null"`
+	Email    string
+	Nickname *string
+}
+
+func TestEmptyStringAsNull(t *testing.T) {
+	dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{EmptyStringAsNull: true})
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&SparseContact{}))
+
+	isNull := func(t *testing.T, id uint, column string) bool {
+		var null bool
+		require.NoError(t, db.Raw("SELECT "+column+" IS NULL FROM sparse_contacts WHERE id = ?", id).Scan(&null).Error)
+		return null
+	}
+
+	empty := ""
+	contact := SparseContact{Name: "", Email: "", Nickname: &empty}
+	require.NoError(t, db.Create(&contact).Error)
+	assert.True(t, isNull(t, contact.ID, "email"))
+	assert.True(t, isNull(t, contact.ID, "nickname"))
+	assert.False(t, isNull(t, contact.ID, "name"), "NOT NULL columns keep the empty string")
+
+	t.Run("Batch", func(t *testing.T) {
+		batch := []SparseContact{{Name: "a", Email: ""}, {Name: "b", Email: "b@example.com"}}
+		require.NoError(t, db.Create(&batch).Error)
+		assert.True(t, isNull(t, batch[0].ID, "email"))
+		assert.False(t, isNull(t, batch[1].ID, "email"))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		filled := SparseContact{Name: "c", Email: "c@example.com"}
+		require.NoError(t, db.Create(&filled).Error)
+		require.NoError(t, db.Model(&filled).Updates(map[string]interface{}{"email": "", "name": ""}).Error)
+		assert.True(t, isNull(t, filled.ID, "email"))
+		assert.False(t, isNull(t, filled.ID, "name"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		plain := setupTestDB(t)
+		require.NoError(t, plain.AutoMigrate(&SparseContact{}))
+		kept := SparseContact{Name: "d", Email: ""}
+		require.NoError(t, plain.Create(&kept).Error)
+
+		var null bool
+		require.NoError(t, plain.Raw("SELECT email IS NULL FROM sparse_contacts WHERE id = ?", kept.ID).Scan(&null).Error)
+		assert.False(t, null)
+	})
+}
+
+type StrictLineItem struct {
+	ID       uint    `gorm:"primaryKey"`
+	Quantity float64 `gorm:"type:integer"`
+	Small    int64   `gorm:"type:smallint"`
+	Price    float64 `gorm:"precision:10;scale:2"`
+}
+
+func TestStrictTypes(t *testing.T) {
+	db, err := gorm.Open(duckdb.OpenWithConfig(":memory:", &duckdb.Config{StrictTypes: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&StrictLineItem{}))
+
+	item := StrictLineItem{Quantity: 3, Small: 7, Price: 9.99}
+	require.NoError(t, db.Create(&item).Error)
+
+	t.Run("Create", func(t *testing.T) {
+		err := db.Create(&StrictLineItem{Quantity: 2.5}).Error
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+		assert.Contains(t, err.Error(), "Quantity")
+
+		err = db.Create(&StrictLineItem{Small: 40000}).Error
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+		assert.Contains(t, err.Error(), "Small")
+
+		err = db.Create(&StrictLineItem{Price: 1.005}).Error
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+		assert.Contains(t, err.Error(), "Price")
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		err := db.Create(&[]StrictLineItem{{Quantity: 1}, {Quantity: 1.5}}).Error
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		err := db.Model(&item).Update("quantity", 4.2).Error
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+
+		require.NoError(t, db.Model(&item).Updates(map[string]interface{}{"quantity": 4.0, "price": 12.5}).Error)
+	})
+
+	var count int64
+	require.NoError(t, db.Model(&StrictLineItem{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "rejected rows are not written")
+
+	var stored StrictLineItem
+	require.NoError(t, db.First(&stored, item.ID).Error)
+	assert.Equal(t, 4.0, stored.Quantity)
+	assert.Equal(t, 12.5, stored.Price)
+
+	t.Run("Disabled", func(t *testing.T) {
+		plain := setupTestDB(t)
+		require.NoError(t, plain.AutoMigrate(&StrictLineItem{}))
+		rounded := StrictLineItem{Quantity: 2.5}
+		require.NoError(t, plain.Create(&rounded).Error)
+	})
+}
+
+func TestQueryColumnTypes(t *testing.T) {
+	query := "SELECT 'a'::VARCHAR AS name, 1::HUGEINT AS big, TIMESTAMP '2024-01-02 03:04:05' AS at, 12.5::DECIMAL(10,2) AS price, [1, 2] AS ids WHERE ?"
+
+	for name, prepare := range map[string]bool{"Direct": false, "Prepared": true} {
+		t.Run(name, func(t *testing.T) {
+			db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+				PrepareStmt: prepare,
+				Logger:      logger.Default.LogMode(logger.Silent),
+			})
+			require.NoError(t, err)
+
+			rows, err := db.Raw(query, true).Rows()
+			require.NoError(t, err)
+			defer rows.Close()
+
+			columnTypes, err := rows.ColumnTypes()
+			require.NoError(t, err)
+			require.Len(t, columnTypes, 5)
+
+			expected := []struct {
+				typeName string
+				scanType string
+			}{
+				{"VARCHAR", "string"},
+				{"HUGEINT", "*big.Int"},
+				{"TIMESTAMP", "time.Time"},
+				{"DECIMAL(10,2)", "duckdb.Decimal"},
+				{"INTEGER[]", "[]interface {}"},
+			}
+			for i, want := range expected {
+				assert.Equal(t, want.typeName, columnTypes[i].DatabaseTypeName())
+				assert.Equal(t, want.scanType, columnTypes[i].ScanType().String())
+
+				_, ok := columnTypes[i].Nullable()
+				assert.False(t, ok, "query results do not carry nullability")
+			}
+
+			precision, scale, ok := columnTypes[3].DecimalSize()
+			require.True(t, ok)
+			assert.Equal(t, int64(10), precision)
+			assert.Equal(t, int64(2), scale)
+
+			_, _, ok = columnTypes[0].DecimalSize()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestPerDatabaseDSNOptions(t *testing.T) {
+	open := func(dsn string) *gorm.DB {
+		db, err := gorm.Open(duckdb.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		return db
+	}
+
+	small := open(":memory:?memory_limit=1GB&threads=1")
+	large := open(":memory:?memory_limit=2GB&threads=2")
+
+	// Check the options on several pooled connections of each database
+	optionsOf := func(db *gorm.DB) []string {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+
+		var values []string
+		var conns []*sql.Conn
+		for i := 0; i < 3; i++ {
+			conn, err := sqlDB.Conn(context.Background())
+			require.NoError(t, err)
+			conns = append(conns, conn)
+
+			var memoryLimit, threads string
+			require.NoError(t, conn.QueryRowContext(context.Background(),
+				"SELECT current_setting('memory_limit')::VARCHAR, current_setting('threads')::VARCHAR").
+				Scan(&memoryLimit, &threads))
+			values = append(values, memoryLimit+"/"+threads)
+		}
+		for _, conn := range conns {
+			require.NoError(t, conn.Close())
+		}
+		return values
+	}
+
+	smallOptions := optionsOf(small)
+	largeOptions := optionsOf(large)
+	for i := range smallOptions {
+		assert.Equal(t, "953.6 MiB/1", smallOptions[i])
+		assert.Equal(t, "1.8 GiB/2", largeOptions[i])
+	}
+}
+
+func TestPerDialectorSettings(t *testing.T) {
+	var connects int32
+	open := func(settings map[string]string) *gorm.DB {
+		dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{
+			Settings: settings,
+			OnConnect: func(execer driver.ExecerContext) error {
+				atomic.AddInt32(&connects, 1)
+				_, err := execer.ExecContext(context.Background(), "CREATE TEMP MACRO greeting() AS 'hello'", nil)
+				return err
+			},
+		})
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		return db
+	}
+
+	small := open(map[string]string{"memory_limit": "1GB", "threads": "1"})
+	large := open(map[string]string{"memory_limit": "2GB", "threads": "2"})
+
+	// Check the settings on several pooled connections of each database
+	settingsOf := func(db *gorm.DB) []string {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+
+		var values []string
+		var conns []*sql.Conn
+		for i := 0; i < 3; i++ {
+			conn, err := sqlDB.Conn(context.Background())
+			require.NoError(t, err)
+			conns = append(conns, conn)
+
+			var memoryLimit, threads, greeting string
+			require.NoError(t, conn.QueryRowContext(context.Background(),
+				"SELECT current_setting('memory_limit')::VARCHAR, current_setting('threads')::VARCHAR, greeting()").
+				Scan(&memoryLimit, &threads, &greeting))
+			assert.Equal(t, "hello", greeting)
+			values = append(values, memoryLimit+"/"+threads)
+		}
+		for _, conn := range conns {
+			require.NoError(t, conn.Close())
+		}
+		return values
+	}
+
+	smallSettings := settingsOf(small)
+	largeSettings := settingsOf(large)
+	for i := range smallSettings {
+		assert.Equal(t, "953.6 MiB/1", smallSettings[i])
+		assert.Equal(t, "1.8 GiB/2", largeSettings[i])
+	}
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&connects), int32(6))
+
+	t.Run("InvalidSetting", func(t *testing.T) {
+		dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{Settings: map[string]string{"no_such_setting": "1"}})
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err == nil {
+			err = db.Exec("SELECT 1").Error
+		}
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no_such_setting")
+	})
+
+	t.Run("ExistingConn", func(t *testing.T) {
+		sqlDB, err := small.DB()
+		require.NoError(t, err)
+
+		_, err = gorm.Open(duckdb.New(duckdb.Config{Conn: sqlDB, Settings: map[string]string{"threads": "1"}}), &gorm.Config{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Conn=true")
+	})
+}
+
+func TestGetConnector(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE connector_rows (id INTEGER, label VARCHAR)").Error)
+
+	connector, err := duckdb.GetConnector(db)
+	require.NoError(t, err)
+	require.NotNil(t, connector)
+
+	again, err := duckdb.GetConnector(db)
+	require.NoError(t, err)
+	assert.Same(t, connector, again)
+
+	// A connection from the connector sees the same database as db
+	conn, err := connector.Connect(context.Background())
+	require.NoError(t, err)
+	execer, ok := conn.(driver.ExecerContext)
+	require.True(t, ok)
+	_, err = execer.ExecContext(context.Background(), "INSERT INTO connector_rows VALUES (1, 'raw')", nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	var label string
+	require.NoError(t, db.Raw("SELECT label FROM connector_rows WHERE id = 1").Scan(&label).Error)
+	assert.Equal(t, "raw", label)
+
+	t.Run("ExistingConn", func(t *testing.T) {
+		sqlDB, err := sql.Open("duckdb", "")
+		require.NoError(t, err)
+		defer func() { _ = sqlDB.Close() }()
+
+		wrapped, err := gorm.Open(duckdb.New(duckdb.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		_, err = duckdb.GetConnector(wrapped)
+		assert.ErrorIs(t, err, duckdb.ErrNoConnector)
+	})
+}
+
+func TestCheckpointThreshold(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "checkpoint.db")
+	dialector := duckdb.OpenWithConfig(dsn, &duckdb.Config{CheckpointThreshold: "1GB"})
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	threshold, err := duckdb.GetSetting(db, "checkpoint_threshold")
+	require.NoError(t, err)
+	assert.Equal(t, "953.6 MiB", threshold)
+
+	require.NoError(t, db.Exec("CREATE TABLE wal_rows AS SELECT range AS id FROM range(1000)").Error)
+	require.NoError(t, duckdb.Checkpoint(db, false))
+	require.NoError(t, duckdb.Checkpoint(db, true))
+
+	t.Run("SettingsTakePrecedence", func(t *testing.T) {
+		dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{
+			CheckpointThreshold: "1GB",
+			Settings:            map[string]string{"checkpoint_threshold": "2GB"},
+		})
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+
+		threshold, err := duckdb.GetSetting(db, "checkpoint_threshold")
+		require.NoError(t, err)
+		assert.Equal(t, "1.8 GiB", threshold)
+	})
+}
+
+func TestMemoryLimitAndThreads(t *testing.T) {
+	dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{MemoryLimit: "1GB", Threads: 3})
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	threads, err := duckdb.GetSetting(db, "threads")
+	require.NoError(t, err)
+	assert.Equal(t, "3", threads)
+
+	memoryLimit, err := duckdb.GetSetting(db, "memory_limit")
+	require.NoError(t, err)
+	assert.Equal(t, "953.6 MiB", memoryLimit)
+
+	t.Run("Invalid", func(t *testing.T) {
+		for name, config := range map[string]*duckdb.Config{
+			"NegativeThreads":    {Threads: -1},
+			"UnparseableLimit":   {MemoryLimit: "lots"},
+			"UnknownMemoryUnits": {MemoryLimit: "4 parsecs"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := gorm.Open(duckdb.OpenWithConfig(":memory:", config), &gorm.Config{})
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestAutoIncrementStrategy(t *testing.T) {
+	t.Run("Sequence", func(t *testing.T) {
+		dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{AutoIncrementStrategy: duckdb.StrategySequence})
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&User{}))
+
+		var columnDefault string
+		require.NoError(t, db.Raw("SELECT column_default FROM information_schema.columns WHERE table_name = 'users' AND column_name = 'id'").Scan(&columnDefault).Error)
+		assert.Equal(t, "nextval('seq_users_id')", columnDefault)
+
+		first := User{Name: "Ada", Email: "ada@example.com", Age: 36}
+		second := User{Name: "Alan", Email: "alan@example.com", Age: 41}
+		require.NoError(t, db.Create(&first).Error)
+		require.NoError(t, db.Create(&second).Error)
+		assert.Equal(t, uint(1), first.ID)
+		assert.Equal(t, uint(2), second.ID)
+	})
+
+	t.Run("Identity", func(t *testing.T) {
+		dialector := duckdb.OpenWithConfig(":memory:", &duckdb.Config{AutoIncrementStrategy: duckdb.StrategyIdentity})
+		_, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		assert.ErrorIs(t, err, duckdb.ErrIdentityUnsupported)
+	})
+}
+
+type LegacyFlag struct {
+	ID       uint
+	Active   bool
+	Archived *bool
+	Enabled  int
+	Visible  *uint8
+}
+
+func TestFlagScanning(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	// Legacy table: integer flags in active/archived, BOOLEAN in enabled/visible
+	require.NoError(t, db.Exec(`CREATE TABLE legacy_flags (
+		id INTEGER, active INTEGER, archived TINYINT, enabled BOOLEAN, visible BOOLEAN)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO legacy_flags VALUES
+		(1, 1, 0, TRUE, FALSE),
+		(2, 0, 2, FALSE, TRUE),
+		(3, 0, NULL, NULL, NULL)`).Error)
+
+	var flags []LegacyFlag
+	require.NoError(t, db.Order("id").Find(&flags).Error)
+	require.Len(t, flags, 3)
+
+	assert.True(t, flags[0].Active)
+	require.NotNil(t, flags[0].Archived)
+	assert.False(t, *flags[0].Archived)
+	assert.Equal(t, 1, flags[0].Enabled)
+	require.NotNil(t, flags[0].Visible)
+	assert.Equal(t, uint8(0), *flags[0].Visible)
+
+	assert.False(t, flags[1].Active)
+	require.NotNil(t, flags[1].Archived)
+	assert.True(t, *flags[1].Archived)
+	assert.Equal(t, 0, flags[1].Enabled)
+	require.NotNil(t, flags[1].Visible)
+	assert.Equal(t, uint8(1), *flags[1].Visible)
+
+	assert.Nil(t, flags[2].Archived)
+	assert.Equal(t, 0, flags[2].Enabled)
+	assert.Nil(t, flags[2].Visible)
+
+	t.Run("BooleanRoundTrip", func(t *testing.T) {
+		type BooleanFlag struct {
+			ID     uint `gorm:"primaryKey"`
+			Active bool
+			Hidden *bool
+		}
+		require.NoError(t, db.AutoMigrate(&BooleanFlag{}))
+
+		var columnType string
+		require.NoError(t, db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'boolean_flags' AND column_name = 'active'").Scan(&columnType).Error)
+		assert.Equal(t, "BOOLEAN", columnType)
+
+		hidden := true
+		require.NoError(t, db.Create(&[]BooleanFlag{{Active: true, Hidden: &hidden}, {Active: false}}).Error)
+
+		var found []BooleanFlag
+		require.NoError(t, db.Order("id").Find(&found).Error)
+		require.Len(t, found, 2)
+		assert.True(t, found[0].Active)
+		require.NotNil(t, found[0].Hidden)
+		assert.True(t, *found[0].Hidden)
+		assert.False(t, found[1].Active)
+		assert.Nil(t, found[1].Hidden)
+	})
+}
+
+func TestPrepareStmtMode(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		PrepareStmt: true,
+		Logger:      logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&User{}))
+
+	_, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	require.True(t, ok, "PrepareStmt should wrap the connection pool")
+
+	for i := 0; i < 3; i++ {
+		user := User{Name: "user", Email: string(rune('a'+i)) + "@example.com", Age: uint8(20 + i)}
+		require.NoError(t, db.Create(&user).Error)
+		assert.Equal(t, uint(i+1), user.ID)
+	}
+
+	t.Run("RepeatedQueries", func(t *testing.T) {
+		for round := 0; round < 2; round++ {
+			for i := 0; i < 3; i++ {
+				age := 20 + i
+
+				var user User
+				require.NoError(t, db.Where("age = ?", age).First(&user).Error)
+				assert.Equal(t, string(rune('a'+i))+"@example.com", user.Email)
+
+				var count int64
+				require.NoError(t, db.Raw("SELECT count(*) FROM users WHERE age >= ?", age).Row().Scan(&count))
+				assert.Equal(t, int64(3-i), count)
+
+				var users []User
+				require.NoError(t, db.Raw("SELECT * FROM users WHERE age >= ?", age).Scan(&users).Error)
+				assert.Len(t, users, 3-i)
+			}
+		}
+	})
+
+	t.Run("UpdatesVisibleToCachedStatements", func(t *testing.T) {
+		var before User
+		require.NoError(t, db.Where("age = ?", 20).First(&before).Error)
+
+		require.NoError(t, db.Model(&User{}).Where("id = ?", before.ID).Update("name", "renamed").Error)
+
+		var after User
+		require.NoError(t, db.Where("age = ?", 20).First(&after).Error)
+		assert.Equal(t, "renamed", after.Name)
+	})
+
+	t.Run("SchemaChangeRebindsStatements", func(t *testing.T) {
+		query := "SELECT * FROM users WHERE age >= ?"
+		var rows []map[string]interface{}
+		require.NoError(t, db.Raw(query, 0).Scan(&rows).Error)
+		require.NotEmpty(t, rows)
+		assert.NotContains(t, rows[0], "nickname")
+
+		require.NoError(t, db.Exec("ALTER TABLE users ADD COLUMN nickname VARCHAR DEFAULT 'nick'").Error)
+
+		rows = nil
+		require.NoError(t, db.Raw(query, 0).Scan(&rows).Error)
+		require.NotEmpty(t, rows)
+		assert.Equal(t, "nick", rows[0]["nickname"])
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			user := User{Name: "tx", Email: "tx@example.com", Age: 50}
+			if err := tx.Create(&user).Error; err != nil {
+				return err
+			}
+			var count int64
+			if err := tx.Model(&User{}).Where("age = ?", 50).Count(&count).Error; err != nil {
+				return err
+			}
+			assert.Equal(t, int64(1), count)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("PrepareErrorsAreTranslated", func(t *testing.T) {
+		var count int64
+		err := db.Raw("SELECT count(*) FROM missing_table WHERE age > ?", 1).Scan(&count).Error
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duckdb driver error")
+
+		// A failed prepare must not be cached: the same SQL works once the table exists
+		require.NoError(t, db.Exec("CREATE TABLE missing_table (age INTEGER)").Error)
+		require.NoError(t, db.Raw("SELECT count(*) FROM missing_table WHERE age > ?", 1).Scan(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestContextCancellationInterruptsQuery(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	const slow = "SELECT count(*) FROM range(10_000_000_000)"
+
+	for _, tc := range []struct {
+		name string
+		run  func(tx *gorm.DB) error
+	}{
+		{"Query", func(tx *gorm.DB) error {
+			var count int64
+			return tx.Raw(slow).Scan(&count).Error
+		}},
+		{"QueryWithArgs", func(tx *gorm.DB) error {
+			var count int64
+			return tx.Raw("SELECT count(*) FROM range(?)", int64(10_000_000_000)).Scan(&count).Error
+		}},
+		{"Exec", func(tx *gorm.DB) error {
+			return tx.Exec("CREATE TEMP TABLE interrupted AS " + slow).Error
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			time.AfterFunc(100*time.Millisecond, cancel)
+
+			start := time.Now()
+			err := tc.run(db.WithContext(ctx))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Less(t, time.Since(start), 5*time.Second, "query kept running after cancellation")
+		})
+	}
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		var count int64
+		err := db.WithContext(ctx).Raw(slow).Scan(&count).Error
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	var one int
+	require.NoError(t, db.Raw("SELECT 1").Scan(&one).Error)
+	assert.Equal(t, 1, one)
+}
+
+func TestQueryCallbackDryRun(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	// users does not exist: a DryRun query must only build its SQL
+	var users []User
+	stmt := db.Session(&gorm.Session{DryRun: true}).
+		Model(&User{}).Select("name", "age").Where("age > ?", 30).Find(&users).Statement
+	require.NoError(t, stmt.Error)
+	assert.Equal(t, `SELECT "name","age" FROM "users" WHERE age > ?`, stmt.SQL.String())
+	assert.Equal(t, []interface{}{30}, stmt.Vars)
+	assert.Empty(t, users)
+}
+
+func TestChainedRowAndRows(t *testing.T) {
+	for _, workaround := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Workaround=%t", workaround), func(t *testing.T) {
+			db, err := gorm.Open(duckdb.OpenWithRowCallbackWorkaround(":memory:", workaround), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			require.NoError(t, err)
+			require.NoError(t, db.AutoMigrate(&User{}))
+			require.NoError(t, db.Create(&User{Name: "ada", Email: "ada@example.com", Age: 36}).Error)
+			require.NoError(t, db.Create(&User{Name: "alan", Email: "alan@example.com", Age: 41}).Error)
+
+			var name string
+			var age uint8
+			row := db.Model(&User{}).Select("name", "age").Where("email = ?", "alan@example.com").Row()
+			require.NotNil(t, row)
+			require.NoError(t, row.Scan(&name, &age))
+			assert.Equal(t, "alan", name)
+			assert.Equal(t, uint8(41), age)
+
+			rows, err := db.Table("users").Select("name").Where("age > ?", 30).Order("name").Rows()
+			require.NoError(t, err)
+			defer rows.Close()
+
+			columns, err := rows.Columns()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"name"}, columns)
+
+			var names []string
+			for rows.Next() {
+				require.NoError(t, rows.Scan(&name))
+				names = append(names, name)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, []string{"ada", "alan"}, names)
+		})
+	}
+}
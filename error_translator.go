@@ -3,6 +3,7 @@ package duckdb
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"gorm.io/gorm"
@@ -27,14 +28,16 @@ func (et ErrorTranslator) Translate(err error) error {
 
 	// Handle DuckDB specific errors
 	switch {
-	case strings.Contains(errStrLower, "unique constraint"):
-		return gorm.ErrDuplicatedKey
+	case strings.Contains(errStrLower, "json extension"):
+		return fmt.Errorf("%w: %s", ErrJSONExtension, errStr)
+	case isDuplicateKeyMessage(errStrLower):
+		return fmt.Errorf("%w: %w", gorm.ErrDuplicatedKey, err)
 	case strings.Contains(errStrLower, "foreign key constraint"):
-		return gorm.ErrForeignKeyViolated
+		return fmt.Errorf("%w: %w", gorm.ErrForeignKeyViolated, err)
 	case strings.Contains(errStrLower, "check constraint"):
-		return gorm.ErrCheckConstraintViolated
+		return constraintError(err, ErrCheckViolation)
 	case strings.Contains(errStrLower, "not null constraint"):
-		return gorm.ErrInvalidValue
+		return constraintError(err, ErrNotNullViolation)
 	case strings.Contains(errStrLower, "no such table"):
 		return gorm.ErrRecordNotFound
 	case strings.Contains(errStrLower, "no such column"):
@@ -60,6 +63,24 @@ func (et ErrorTranslator) Translate(err error) error {
 	return err
 }
 
+// constraintError returns the typed error translateDriverError attaches to
+// err, so both paths report a violation identically, or err wrapped with
+// sentinel when its message is not one translateDriverError recognizes
+func constraintError(err, sentinel error) error {
+	if classified := classifyDriverError(err); errors.Is(classified, sentinel) {
+		return classified
+	}
+	return fmt.Errorf("%w: %w", sentinel, err)
+}
+
+// isDuplicateKeyMessage reports whether a lower-cased error message is one of
+// DuckDB's (or SQLite's) primary key and unique constraint violations
+func isDuplicateKeyMessage(errStrLower string) bool {
+	return strings.Contains(errStrLower, "unique constraint") ||
+		strings.Contains(errStrLower, "duplicate key") ||
+		strings.Contains(errStrLower, "primary key constraint")
+}
+
 // Common DuckDB error patterns
 var (
 	ErrUniqueConstraint  = errors.New("UNIQUE constraint failed")
@@ -70,6 +91,10 @@ var (
 	ErrNoSuchColumn      = errors.New("no such column")
 	ErrSyntaxError       = errors.New("syntax error")
 	ErrDatabaseLocked    = errors.New("database is locked")
+
+	// ErrJSONExtension reports a JSON function or type used while DuckDB's
+	// json extension is not installed or loaded
+	ErrJSONExtension = errors.New("duckdb: json extension is not loaded")
 )
 
 // IsSpecificError checks if an error matches a specific DuckDB error type
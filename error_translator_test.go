@@ -86,6 +86,15 @@ func TestErrorTranslator_Translate(t *testing.T) {
 	}
 }
 
+func TestErrorTranslator_JSONExtension(t *testing.T) {
+	translator := duckdb.ErrorTranslator{}
+	err := errors.New(`Catalog Error: Scalar Function with name "json_extract" is not in the catalog, but it exists in the json extension.`)
+
+	translated := translator.Translate(err)
+	assert.ErrorIs(t, translated, duckdb.ErrJSONExtension)
+	assert.Contains(t, translated.Error(), "json_extract")
+}
+
 func TestErrorTranslator_IsDuplicateKeyError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -332,3 +341,79 @@ func TestErrorTranslator_RealDatabaseErrors(t *testing.T) {
 	err = db.Select("non_existent_column").First(&TestErrorModel{}).Error
 	assert.Error(t, err)
 }
+
+func TestConstraintViolationErrors(t *testing.T) {
+	for _, translate := range []bool{false, true} {
+		t.Run(map[bool]string{false: "DriverErrors", true: "TranslateError"}[translate], func(t *testing.T) {
+			db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{TranslateError: translate})
+			require.NoError(t, err)
+			require.NoError(t, db.AutoMigrate(&TestErrorModel{}))
+
+			require.NoError(t, db.Create(&TestErrorModel{ID: 1, Email: "a@example.com", Name: "A"}).Error)
+
+			err = db.Create(&TestErrorModel{ID: 1, Email: "b@example.com", Name: "B"}).Error
+			assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+			assert.Contains(t, err.Error(), "Duplicate key")
+
+			err = db.Create(&TestErrorModel{ID: 2, Email: "a@example.com", Name: "C"}).Error
+			assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+			require.NoError(t, db.Exec("CREATE TABLE error_parents (id INTEGER PRIMARY KEY)").Error)
+			require.NoError(t, db.Exec("CREATE TABLE error_children (id INTEGER, parent_id INTEGER REFERENCES error_parents(id))").Error)
+			err = db.Exec("INSERT INTO error_children VALUES (1, 42)").Error
+			assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+		})
+	}
+}
+
+func TestErrorTranslator_ConstraintMessages(t *testing.T) {
+	translator := duckdb.ErrorTranslator{}
+
+	for message, expected := range map[string]error{
+		`Constraint Error: Duplicate key "id: 1" violates primary key constraint.`:                                      gorm.ErrDuplicatedKey,
+		`Constraint Error: Duplicate key "email: a@example.com" violates unique constraint.`:                            gorm.ErrDuplicatedKey,
+		`Constraint Error: PRIMARY KEY or UNIQUE constraint violated: duplicate key "1"`:                                gorm.ErrDuplicatedKey,
+		`Constraint Error: Violates foreign key constraint because key "id: 42" does not exist in the referenced table`: gorm.ErrForeignKeyViolated,
+	} {
+		original := errors.New(message)
+		translated := translator.Translate(original)
+		assert.ErrorIs(t, translated, expected, message)
+		assert.ErrorIs(t, translated, original, "original error is preserved")
+	}
+}
+
+func TestNotNullAndCheckViolations(t *testing.T) {
+	for _, translate := range []bool{false, true} {
+		t.Run(map[bool]string{false: "DriverErrors", true: "TranslateError"}[translate], func(t *testing.T) {
+			db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{TranslateError: translate})
+			require.NoError(t, err)
+			require.NoError(t, db.Exec("CREATE TABLE validated_rows (id INTEGER, name VARCHAR NOT NULL, qty INTEGER CHECK (qty >= 0))").Error)
+
+			err = db.Exec("INSERT INTO validated_rows VALUES (1, NULL, 1)").Error
+			assert.ErrorIs(t, err, duckdb.ErrNotNullViolation)
+			assert.NotErrorIs(t, err, gorm.ErrInvalidValue)
+			assert.NotErrorIs(t, err, duckdb.ErrCheckViolation)
+			assert.Contains(t, err.Error(), "NOT NULL constraint failed")
+
+			err = db.Exec("INSERT INTO validated_rows VALUES (2, 'widget', -1)").Error
+			assert.ErrorIs(t, err, duckdb.ErrCheckViolation)
+			assert.ErrorIs(t, err, gorm.ErrCheckConstraintViolated)
+			assert.NotErrorIs(t, err, duckdb.ErrNotNullViolation)
+		})
+	}
+
+	t.Run("TranslateMessages", func(t *testing.T) {
+		translator := duckdb.ErrorTranslator{}
+
+		original := errors.New("Constraint Error: NOT NULL constraint failed: validated_rows.name")
+		translated := translator.Translate(original)
+		assert.ErrorIs(t, translated, duckdb.ErrNotNullViolation)
+		assert.ErrorIs(t, translated, original)
+		assert.Equal(t, original.Error(), translated.Error())
+
+		original = errors.New("Constraint Error: CHECK constraint failed: validated_rows")
+		translated = translator.Translate(original)
+		assert.ErrorIs(t, translated, duckdb.ErrCheckViolation)
+		assert.ErrorIs(t, translated, original)
+	})
+}
@@ -298,7 +298,7 @@ func demonstrateAdvancedQueries(db *gorm.DB) {
 
 	var userStats []UserStat
 	db.Model(&User{}).
-		Select("CASE WHEN age < 30 THEN 'Young' ELSE 'Mature' END as age_group, COUNT(*) as count").
+		Select("? as age_group, COUNT(*) as count", duckdb.If(gorm.Expr("age < ?", 30), "Young", "Mature")).
 		Group("age_group").
 		Scan(&userStats)
 
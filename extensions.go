@@ -3,11 +3,17 @@ package duckdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // Extension represents a DuckDB extension with its metadata and status
@@ -18,6 +24,39 @@ type Extension struct {
 	Installed   bool   `json:"installed"`
 	BuiltIn     bool   `json:"built_in,omitempty"`
 	Version     string `json:"version,omitempty"`
+	InstallPath string `json:"install_path,omitempty"`
+	InstallMode string `json:"install_mode,omitempty"`
+}
+
+// extensionColumns selects the duckdb_extensions() columns scanExtension reads
+const extensionColumns = `
+	extension_name,
+	loaded,
+	installed,
+	description,
+	extension_version,
+	install_path,
+	install_mode
+`
+
+// installModeStatic is the install_mode of extensions compiled into DuckDB
+const installModeStatic = "STATICALLY_LINKED"
+
+// scanExtension scans one row of extensionColumns
+func scanExtension(scan func(dest ...interface{}) error) (Extension, error) {
+	var ext Extension
+	var description, version, path, mode sql.NullString
+
+	if err := scan(&ext.Name, &ext.Loaded, &ext.Installed, &description, &version, &path, &mode); err != nil {
+		return ext, err
+	}
+
+	ext.Description = description.String
+	ext.Version = version.String
+	ext.InstallPath = path.String
+	ext.InstallMode = mode.String
+	ext.BuiltIn = mode.String == installModeStatic
+	return ext, nil
 }
 
 // ExtensionConfig holds configuration for extension management
@@ -31,7 +70,20 @@ type ExtensionConfig struct {
 	// Timeout for extension operations (0 = no timeout)
 	Timeout time.Duration
 
+	// Repository is the extension repository INSTALL downloads from, passed
+	// as INSTALL ... FROM, e.g. a mirror on a private network.
+	// Default: "" (DuckDB's default repository)
+	Repository string
+
+	// LocalPath is a directory of <name>.duckdb_extension files. Extensions
+	// found there are installed from the file instead of a repository, which
+	// lets AutoInstall work in air-gapped environments.
+	// Default: "" (no local directory)
+	LocalPath string
+
 	// RepositoryURL custom extension repository URL
+	//
+	// Deprecated: use Repository, which takes precedence.
 	RepositoryURL string
 
 	// AllowUnsigned allows loading unsigned extensions (security risk)
@@ -42,6 +94,9 @@ type ExtensionConfig struct {
 type ExtensionManager struct {
 	db     *gorm.DB
 	config *ExtensionConfig
+
+	mu       sync.Mutex
+	attached map[string]string // alias -> path of databases attached through Attach
 }
 
 // Common DuckDB extensions
@@ -91,8 +146,9 @@ func NewExtensionManager(db *gorm.DB, config *ExtensionConfig) *ExtensionManager
 	}
 
 	return &ExtensionManager{
-		db:     db,
-		config: config,
+		db:       db,
+		config:   config,
+		attached: make(map[string]string),
 	}
 }
 
@@ -108,15 +164,7 @@ func (m *ExtensionManager) ListExtensions() ([]Extension, error) {
 	var extensions []Extension
 
 	// Query duckdb_extensions() function to get extension information
-	query := `
-		SELECT 
-			extension_name as name,
-			loaded,
-			installed,
-			description
-		FROM duckdb_extensions()
-		ORDER BY extension_name
-	`
+	query := "SELECT " + extensionColumns + " FROM duckdb_extensions() ORDER BY extension_name"
 
 	rows, err := m.db.WithContext(ctx).Raw(query).Rows()
 	if err != nil {
@@ -130,17 +178,11 @@ func (m *ExtensionManager) ListExtensions() ([]Extension, error) {
 	}()
 
 	for rows.Next() {
-		var ext Extension
-		var description sql.NullString
-
-		if err := rows.Scan(&ext.Name, &ext.Loaded, &ext.Installed, &description); err != nil {
+		ext, err := scanExtension(rows.Scan)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan extension row: %w", err)
 		}
 
-		if description.Valid {
-			ext.Description = description.String
-		}
-
 		extensions = append(extensions, ext)
 	}
 
@@ -160,22 +202,9 @@ func (m *ExtensionManager) GetExtension(name string) (*Extension, error) {
 		defer cancel()
 	}
 
-	query := `
-		SELECT 
-			extension_name as name,
-			loaded,
-			installed,
-			description
-		FROM duckdb_extensions()
-		WHERE extension_name = ?
-	`
+	query := "SELECT " + extensionColumns + " FROM duckdb_extensions() WHERE extension_name = ?"
 
-	var ext Extension
-	var description sql.NullString
-
-	err := m.db.WithContext(ctx).Raw(query, name).Row().Scan(
-		&ext.Name, &ext.Loaded, &ext.Installed, &description,
-	)
+	ext, err := scanExtension(m.db.WithContext(ctx).Raw(query, name).Row().Scan)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("extension '%s' not found", name)
@@ -183,10 +212,6 @@ func (m *ExtensionManager) GetExtension(name string) (*Extension, error) {
 		return nil, fmt.Errorf("failed to get extension '%s': %w", name, err)
 	}
 
-	if description.Valid {
-		ext.Description = description.String
-	}
-
 	return &ext, nil
 }
 
@@ -206,12 +231,14 @@ func (m *ExtensionManager) LoadExtension(name string) error {
 
 	// Install extension if auto-install is enabled and extension is not installed
 	if m.config.AutoInstall {
+		// duckdb_extensions() only lists extensions DuckDB knows of, so one
+		// missing from it may still come from LocalPath or a custom repository
 		ext, err := m.GetExtension(name)
-		if err != nil {
+		if err != nil && m.config.LocalPath == "" && m.repository() == "" {
 			return fmt.Errorf("failed to check extension status: %w", err)
 		}
 
-		if !ext.Installed {
+		if err != nil || !ext.Installed {
 			if err := m.InstallExtension(name); err != nil {
 				return fmt.Errorf("failed to install extension '%s': %w", name, err)
 			}
@@ -242,15 +269,56 @@ func (m *ExtensionManager) InstallExtension(name string) error {
 		return nil // Already installed
 	}
 
-	// Install the extension
+	db := m.db.WithContext(ctx)
+
+	if m.config.LocalPath != "" {
+		file := filepath.Join(m.config.LocalPath, m.quoteName(name)+".duckdb_extension")
+		if _, err := os.Stat(file); err == nil {
+			if err := db.Exec("INSTALL " + sqlStringLiteral(file)).Error; err != nil {
+				return fmt.Errorf("failed to install extension '%s' from %s: %w", name, file, err)
+			}
+			return nil
+		}
+	}
+
+	// Install the extension, from the configured repository if there is one
 	query := fmt.Sprintf("INSTALL %s", m.quoteName(name))
-	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
-		return fmt.Errorf("failed to install extension '%s': %w", name, err)
+	if repository := m.repository(); repository != "" {
+		query += " FROM " + sqlStringLiteral(repository)
+	}
+	if err := db.Exec(query).Error; err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrExtensionUnavailable, m.installSources(name), err)
 	}
 
 	return nil
 }
 
+// ErrExtensionUnavailable is returned by InstallExtension, and LoadExtension
+// with AutoInstall, when neither ExtensionConfig.LocalPath nor the
+// repository provides the extension.
+var ErrExtensionUnavailable = errors.New("duckdb: extension could not be installed")
+
+// repository returns the configured extension repository, if any
+func (m *ExtensionManager) repository() string {
+	if m.config.Repository != "" {
+		return m.config.Repository
+	}
+	return m.config.RepositoryURL
+}
+
+// installSources describes where InstallExtension looked for name
+func (m *ExtensionManager) installSources(name string) string {
+	repository := m.repository()
+	if repository == "" {
+		repository = "the default repository"
+	}
+	if m.config.LocalPath == "" {
+		return fmt.Sprintf("extension '%s' not found in %s", name, repository)
+	}
+	return fmt.Sprintf("extension '%s' not found as %s.duckdb_extension in %s or in %s",
+		name, m.quoteName(name), m.config.LocalPath, repository)
+}
+
 // IsExtensionLoaded checks if an extension is currently loaded
 func (m *ExtensionManager) IsExtensionLoaded(name string) bool {
 	ext, err := m.GetExtension(name)
@@ -296,6 +364,154 @@ func (m *ExtensionManager) PreloadExtensions() error {
 	return m.LoadExtensions(m.config.PreloadExtensions)
 }
 
+// ImportOptions configures ImportParquet
+type ImportOptions struct {
+	// ColumnMapping renames file columns to table columns, keyed by the
+	// column name in the file. Other columns are matched by name.
+	ColumnMapping map[string]string
+
+	// CreateTableIfNotExists creates the table from the file's schema, as
+	// reported by DESCRIBE, when it does not exist yet
+	CreateTableIfNotExists bool
+}
+
+// ImportParquet loads a Parquet file into tableName with
+// INSERT INTO table BY NAME SELECT * FROM read_parquet('path'), loading the
+// parquet extension first if it is not loaded. Columns are matched by name.
+// Pass nil options for the defaults. It returns the number of rows loaded.
+// A missing file fails with ErrFileNotFound and columns that do not fit the
+// table with ErrSchemaMismatch.
+func (m *ExtensionManager) ImportParquet(tableName, filePath string, options *ImportOptions) (int64, error) {
+	if options == nil {
+		options = &ImportOptions{}
+	}
+	if !m.IsExtensionLoaded(ExtensionParquet) {
+		if err := m.LoadExtension(ExtensionParquet); err != nil {
+			return 0, fmt.Errorf("failed to import Parquet into %s: %w", tableName, err)
+		}
+	}
+
+	db := m.db.Session(&gorm.Session{NewDB: true})
+	source := "SELECT *" + parquetRename(options.ColumnMapping) +
+		" FROM read_parquet(" + sqlStringLiteral(filePath) + ")"
+
+	if options.CreateTableIfNotExists && !db.Migrator().HasTable(tableName) {
+		if err := createTableFromQuery(db, tableName, source); err != nil {
+			return 0, fmt.Errorf("failed to create %s from %s: %w", tableName, filePath, err)
+		}
+	}
+
+	result := db.Exec("INSERT INTO " + db.Statement.Quote(tableName) + " BY NAME " + source)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to import Parquet from %s into %s: %w", filePath, tableName, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// parquetRename renders a RENAME (...) star modifier for mapping
+func parquetRename(mapping map[string]string) string {
+	if len(mapping) == 0 {
+		return ""
+	}
+
+	sources := make([]string, 0, len(mapping))
+	for source := range mapping {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	renames := make([]string, len(sources))
+	for i, source := range sources {
+		renames[i] = sqlIdentifier(source) + " AS " + sqlIdentifier(mapping[source])
+	}
+	return " RENAME (" + strings.Join(renames, ", ") + ")"
+}
+
+// createTableFromQuery creates table with the columns DESCRIBE reports for query
+func createTableFromQuery(db *gorm.DB, table, query string) error {
+	var columns []struct {
+		ColumnName string
+		ColumnType string
+	}
+	if err := db.Raw("SELECT column_name, column_type FROM (DESCRIBE " + query + ")").Scan(&columns).Error; err != nil {
+		return fmt.Errorf("failed to describe source: %w", err)
+	}
+
+	definitions := make([]string, len(columns))
+	for i, column := range columns {
+		definitions[i] = sqlIdentifier(column.ColumnName) + " " + column.ColumnType
+	}
+	return db.Exec("CREATE TABLE " + db.Statement.Quote(table) + " (" + strings.Join(definitions, ", ") + ")").Error
+}
+
+// ErrAlreadyAttached is returned by Attach when the alias is already in use
+// or the database file is already attached under another alias.
+var ErrAlreadyAttached = errors.New("duckdb: database already attached")
+
+// Attach attaches the database file at path under alias with
+// ATTACH 'path' AS alias, opening it read-only when readOnly is set. Its
+// tables are then queryable as alias.schema.table (or alias.table for the
+// main schema) from any session on this database.
+func (m *ExtensionManager) Attach(path, alias string, readOnly bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.attached[alias]; ok {
+		return fmt.Errorf("%w: alias %q is attached to %s", ErrAlreadyAttached, alias, existing)
+	}
+
+	query := "ATTACH " + sqlStringLiteral(path) + " AS " + sqlIdentifier(alias)
+	if readOnly {
+		query += " (READ_ONLY)"
+	}
+	if err := m.db.Session(&gorm.Session{NewDB: true}).Exec(query).Error; err != nil {
+		if isAlreadyAttachedError(err) {
+			return fmt.Errorf("%w: failed to attach %s as %q: %v", ErrAlreadyAttached, path, alias, err)
+		}
+		return fmt.Errorf("failed to attach %s as %q: %w", path, alias, err)
+	}
+
+	m.attached[alias] = path
+	return nil
+}
+
+// Detach detaches a database previously attached through Attach.
+func (m *ExtensionManager) Detach(alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.attached[alias]; !ok {
+		return fmt.Errorf("failed to detach %q: no database is attached under that alias", alias)
+	}
+
+	if err := m.db.Session(&gorm.Session{NewDB: true}).Exec("DETACH " + sqlIdentifier(alias)).Error; err != nil {
+		return fmt.Errorf("failed to detach %q: %w", alias, err)
+	}
+
+	delete(m.attached, alias)
+	return nil
+}
+
+// AttachedDatabases returns the databases attached through Attach, keyed by alias.
+func (m *ExtensionManager) AttachedDatabases() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attached := make(map[string]string, len(m.attached))
+	for alias, path := range m.attached {
+		attached[alias] = path
+	}
+	return attached
+}
+
+// isAlreadyAttachedError reports DuckDB's errors for a reused alias or a file
+// attached twice
+func isAlreadyAttachedError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already attached") ||
+		(strings.Contains(msg, "database with name") && strings.Contains(msg, "already exists"))
+}
+
 // quoteName safely quotes an extension name for SQL
 func (m *ExtensionManager) quoteName(name string) string {
 	// Remove any potentially dangerous characters
@@ -306,6 +522,10 @@ func (m *ExtensionManager) quoteName(name string) string {
 	return cleaned
 }
 
+// ErrRemoteAccessUnavailable is returned by ExtensionHelper.EnableRemoteAccess
+// and SetS3Credentials when the httpfs extension cannot be used.
+var ErrRemoteAccessUnavailable = errors.New("duckdb: remote access via httpfs is unavailable")
+
 // ExtensionHelper provides convenience methods for common extension operations
 type ExtensionHelper struct {
 	manager *ExtensionManager
@@ -370,6 +590,75 @@ func (h *ExtensionHelper) EnableCloudAccess() error {
 	return h.manager.LoadExtensions(cloudExtensions)
 }
 
+// EnableRemoteAccess installs and loads httpfs, after which http://,
+// https:// and s3:// URLs can be read directly:
+//
+//	helper.EnableRemoteAccess()
+//	helper.SetS3Credentials("eu-west-1", keyID, secret, "")
+//	duckdb.RegisterView(db, "events", duckdb.ReadParquet("s3://bucket/events/*.parquet"))
+//
+// httpfs is not bundled into every DuckDB build; when it cannot be loaded
+// and cannot be installed either, typically because the extension
+// repository is unreachable, ErrRemoteAccessUnavailable is returned.
+func (h *ExtensionHelper) EnableRemoteAccess() error {
+	if err := h.manager.LoadExtension(ExtensionHTTPS); err != nil {
+		return fmt.Errorf("%w: httpfs is not bundled into this DuckDB build and could not be installed "+
+			"(installing needs access to the extension repository, or AutoInstall is off): %v",
+			ErrRemoteAccessUnavailable, err)
+	}
+	return nil
+}
+
+// s3SecretName names the DuckDB secret SetS3Credentials creates
+const s3SecretName = "gorm_duckdb_s3"
+
+// SetS3Credentials stores the credentials httpfs uses for s3:// URLs as a
+// DuckDB S3 secret (CREATE OR REPLACE SECRET), so calling it again replaces
+// them. Empty arguments are left out; endpoint is only needed for
+// S3-compatible services other than AWS. The secret lives in memory and
+// applies to every connection of the database. The statement carrying it
+// bypasses the GORM logger, LogRenderedSQL and LastStatement, and the secret
+// is masked in returned errors. Call EnableRemoteAccess first.
+func (h *ExtensionHelper) SetS3Credentials(region, keyID, secret, endpoint string) error {
+	if !h.manager.IsExtensionLoaded(ExtensionHTTPS) {
+		return fmt.Errorf("%w: httpfs is not loaded; call EnableRemoteAccess first", ErrRemoteAccessUnavailable)
+	}
+
+	options := []string{"TYPE S3"}
+	for _, option := range []struct{ name, value string }{
+		{"KEY_ID", keyID},
+		{"SECRET", secret},
+		{"REGION", region},
+		{"ENDPOINT", endpoint},
+	} {
+		if option.value != "" {
+			options = append(options, option.name+" "+sqlStringLiteral(option.value))
+		}
+	}
+
+	tx := h.manager.db.Session(&gorm.Session{NewDB: true, Logger: logger.Discard}).Set(sensitiveStatementKey, true)
+	if err := tx.Exec("CREATE OR REPLACE SECRET " + s3SecretName + " (" + strings.Join(options, ", ") + ")").Error; err != nil {
+		return fmt.Errorf("failed to create S3 secret: %w", redactedError{err: err, secret: secret})
+	}
+	return nil
+}
+
+// redactedError masks secret wherever err's message repeats it, e.g. when
+// DuckDB quotes the failing statement
+type redactedError struct {
+	err    error
+	secret string
+}
+
+func (e redactedError) Error() string {
+	if e.secret == "" {
+		return e.err.Error()
+	}
+	return strings.ReplaceAll(e.err.Error(), e.secret, "***")
+}
+
+func (e redactedError) Unwrap() error { return e.err }
+
 // EnableSpatial loads geospatial extensions
 func (h *ExtensionHelper) EnableSpatial() error {
 	return h.manager.LoadExtension(ExtensionSpatial)
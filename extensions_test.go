@@ -1,12 +1,19 @@
 package duckdb_test
 
 import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
 )
@@ -111,6 +118,25 @@ func TestExtensionManager_GetExtension_JSON(t *testing.T) {
 	// JSON is usually built-in and loaded by default
 }
 
+func TestExtensionManager_ExtensionVersions(t *testing.T) {
+	_, manager := setupBasicExtensionTestDB(t)
+	require.NoError(t, manager.LoadExtension("json"))
+
+	ext, err := manager.GetExtension("json")
+	require.NoError(t, err)
+	assert.NotEmpty(t, ext.Version)
+	assert.NotEmpty(t, ext.InstallMode)
+	assert.Equal(t, ext.InstallMode == "STATICALLY_LINKED", ext.BuiltIn)
+
+	extensions, err := manager.ListExtensions()
+	require.NoError(t, err)
+	for _, listed := range extensions {
+		if listed.Name == "json" {
+			assert.Equal(t, *ext, listed)
+		}
+	}
+}
+
 func TestExtensionManager_GetExtension_NotFound(t *testing.T) {
 	_, manager := setupBasicExtensionTestDB(t)
 
@@ -268,6 +294,50 @@ func TestExtensionHelper_EnableCloudAccess(t *testing.T) {
 	_ = err // Don't assert, just ensure it doesn't panic
 }
 
+func TestExtensionHelper_EnableRemoteAccess(t *testing.T) {
+	// Capture both the GORM logger and the standard logger LogRenderedSQL uses
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(originalOutput)
+
+	db, err := gorm.Open(duckdb.OpenWithConfig(":memory:", &duckdb.Config{LogRenderedSQL: true}), &gorm.Config{
+		Logger: logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Info}),
+	})
+	require.NoError(t, err)
+	manager := duckdb.NewExtensionManager(db, nil)
+	helper := duckdb.NewExtensionHelper(manager)
+
+	const secret = "wJalrXUtnFEMI-it's-secret"
+
+	if !manager.IsExtensionLoaded("httpfs") {
+		err := helper.SetS3Credentials("eu-west-1", "key", secret, "")
+		assert.ErrorIs(t, err, duckdb.ErrRemoteAccessUnavailable)
+	}
+
+	if err := helper.EnableRemoteAccess(); err != nil {
+		assert.ErrorIs(t, err, duckdb.ErrRemoteAccessUnavailable)
+		t.Skipf("httpfs unavailable: %v", err)
+	}
+	assert.True(t, manager.IsExtensionLoaded("httpfs"))
+
+	require.NoError(t, helper.SetS3Credentials("eu-west-1", "AKIAEXAMPLE", secret, "minio.local:9000"))
+	// Calling it again replaces the secret
+	require.NoError(t, helper.SetS3Credentials("eu-central-1", "AKIAEXAMPLE", secret, ""))
+
+	var secrets []struct {
+		Name string
+		Type string
+	}
+	require.NoError(t, db.Raw("SELECT name, type FROM duckdb_secrets()").Scan(&secrets).Error)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "gorm_duckdb_s3", secrets[0].Name)
+	assert.Equal(t, "s3", secrets[0].Type)
+
+	assert.NotEmpty(t, buf.String(), "the captured loggers saw the other statements")
+	assert.NotContains(t, buf.String(), "wJalrXUtnFEMI")
+}
+
 func TestExtensionHelper_EnableSpatial(t *testing.T) {
 	_, manager := setupBasicExtensionTestDB(t)
 	helper := duckdb.NewExtensionHelper(manager)
@@ -436,6 +506,66 @@ func TestExtensionConfig_Struct(t *testing.T) {
 	assert.False(t, config.AllowUnsigned)
 }
 
+func TestExtensionManager_OfflineRepository(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	var before string
+	require.NoError(t, db.Raw("SELECT current_setting('custom_extension_repository')").Scan(&before).Error)
+
+	localPath := t.TempDir()
+	manager := duckdb.NewExtensionManager(db, &duckdb.ExtensionConfig{
+		AutoInstall: true,
+		Timeout:     30 * time.Second,
+		Repository:  "http://127.0.0.1:1",
+		LocalPath:   localPath,
+	})
+
+	err = manager.LoadExtension("offline_only_extension")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, duckdb.ErrExtensionUnavailable))
+	assert.Contains(t, err.Error(), localPath)
+	assert.Contains(t, err.Error(), "http://127.0.0.1:1")
+
+	// The repository is passed to INSTALL, not set for the whole database
+	var after string
+	require.NoError(t, db.Raw("SELECT current_setting('custom_extension_repository')").Scan(&after).Error)
+	assert.Equal(t, before, after)
+}
+
+func TestExtensionManager_InstallSources(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Record INSTALL statements and run a no-op in their place, so the
+	// installs succeed without network access or a signed extension file
+	var installs []string
+	require.NoError(t, db.Callback().Raw().Before("gorm:raw").Register("test:record_install", func(tx *gorm.DB) {
+		if query := tx.Statement.SQL.String(); strings.HasPrefix(query, "INSTALL ") {
+			installs = append(installs, query)
+			tx.Statement.SQL.Reset()
+			tx.Statement.SQL.WriteString("SELECT 1")
+		}
+	}))
+
+	localPath := t.TempDir()
+	vendored := filepath.Join(localPath, "vendored_extension.duckdb_extension")
+	require.NoError(t, os.WriteFile(vendored, []byte("stub"), 0o600))
+
+	manager := duckdb.NewExtensionManager(db, &duckdb.ExtensionConfig{
+		Timeout:    30 * time.Second,
+		Repository: "https://extensions.example.internal/duckdb",
+		LocalPath:  localPath,
+	})
+
+	require.NoError(t, manager.InstallExtension("vendored_extension"))
+	require.NoError(t, manager.InstallExtension("mirrored_extension"))
+	assert.Equal(t, []string{
+		"INSTALL '" + vendored + "'",
+		"INSTALL mirrored_extension FROM 'https://extensions.example.internal/duckdb'",
+	}, installs)
+}
+
 func TestExtensionManager_Timeout(t *testing.T) {
 	db, _ := setupBasicExtensionTestDB(t)
 
@@ -470,3 +600,101 @@ func TestExtensionManager_QuoteName(t *testing.T) {
 		_ = err // Will likely error due to extension not existing, but shouldn't crash
 	}
 }
+
+type ParquetVisit struct {
+	ID      int
+	Page    string
+	Visitor string
+}
+
+func TestExtensionManager_ImportParquet(t *testing.T) {
+	db, manager := setupBasicExtensionTestDB(t)
+
+	path := filepath.Join(t.TempDir(), "visits.parquet")
+	require.NoError(t, db.Exec("COPY (SELECT * FROM (VALUES (1, 'home', 'ann'), (2, 'docs', 'bob')) AS v(id, page, user_name)) TO '"+path+"' (FORMAT PARQUET)").Error)
+
+	t.Run("ColumnMapping", func(t *testing.T) {
+		require.NoError(t, db.AutoMigrate(&ParquetVisit{}))
+
+		imported, err := manager.ImportParquet("parquet_visits", path, &duckdb.ImportOptions{
+			ColumnMapping: map[string]string{"user_name": "visitor"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), imported)
+
+		var visits []ParquetVisit
+		require.NoError(t, db.Order("id").Find(&visits).Error)
+		assert.Equal(t, []ParquetVisit{{1, "home", "ann"}, {2, "docs", "bob"}}, visits)
+	})
+
+	t.Run("CreateTableIfNotExists", func(t *testing.T) {
+		imported, err := manager.ImportParquet("raw_visits", path, &duckdb.ImportOptions{CreateTableIfNotExists: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), imported)
+
+		var users []string
+		require.NoError(t, db.Table("raw_visits").Order("id").Pluck("user_name", &users).Error)
+		assert.Equal(t, []string{"ann", "bob"}, users)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := manager.ImportParquet("parquet_visits", filepath.Join(t.TempDir(), "missing.parquet"), nil)
+		assert.True(t, errors.Is(err, duckdb.ErrFileNotFound), "got %v", err)
+
+		// user_name has no matching column without the mapping
+		_, err = manager.ImportParquet("parquet_visits", path, nil)
+		assert.True(t, errors.Is(err, duckdb.ErrSchemaMismatch), "got %v", err)
+	})
+}
+
+func TestExtensionManager_Attach(t *testing.T) {
+	db, manager := setupBasicExtensionTestDB(t)
+	archivePath := filepath.Join(t.TempDir(), "archive.duckdb")
+
+	require.NoError(t, db.Exec("CREATE TABLE customers (id INTEGER, name VARCHAR)").Error)
+	require.NoError(t, db.Exec("INSERT INTO customers VALUES (1, 'ann'), (2, 'bob')").Error)
+
+	require.NoError(t, manager.Attach(archivePath, "archive", false))
+	require.NoError(t, db.Exec("CREATE TABLE archive.main.orders (customer_id INTEGER, total INTEGER)").Error)
+	require.NoError(t, db.Exec("INSERT INTO archive.orders VALUES (1, 10), (1, 5), (2, 7)").Error)
+	assert.Equal(t, map[string]string{"archive": archivePath}, manager.AttachedDatabases())
+	require.NoError(t, manager.Detach("archive"))
+
+	t.Run("ReadOnlyCrossDatabaseQuery", func(t *testing.T) {
+		require.NoError(t, manager.Attach(archivePath, "archive", true))
+		defer func() { require.NoError(t, manager.Detach("archive")) }()
+
+		var totals []struct {
+			Name  string
+			Total int
+		}
+		require.NoError(t, db.Table("customers").
+			Select("customers.name, SUM(o.total) AS total").
+			Joins("JOIN archive.main.orders o ON o.customer_id = customers.id").
+			Group("customers.name").Order("customers.name").
+			Scan(&totals).Error)
+		require.Len(t, totals, 2)
+		assert.Equal(t, "ann", totals[0].Name)
+		assert.Equal(t, 15, totals[0].Total)
+		assert.Equal(t, 7, totals[1].Total)
+
+		assert.Error(t, db.Exec("INSERT INTO archive.orders VALUES (3, 1)").Error)
+	})
+
+	t.Run("AlreadyAttached", func(t *testing.T) {
+		require.NoError(t, manager.Attach(archivePath, "archive", true))
+		defer func() { require.NoError(t, manager.Detach("archive")) }()
+
+		err := manager.Attach(filepath.Join(t.TempDir(), "other.duckdb"), "archive", false)
+		assert.True(t, errors.Is(err, duckdb.ErrAlreadyAttached), "got %v", err)
+
+		err = manager.Attach(archivePath, "archive_copy", true)
+		assert.True(t, errors.Is(err, duckdb.ErrAlreadyAttached), "got %v", err)
+	})
+
+	t.Run("DetachUnknownAlias", func(t *testing.T) {
+		err := manager.Detach("missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+	})
+}
@@ -5,6 +5,8 @@ go 1.24.0
 toolchain go1.24.6
 
 require (
+	github.com/go-viper/mapstructure/v2 v2.4.0
+	github.com/google/uuid v1.6.0
 	github.com/marcboeker/go-duckdb/v2 v2.4.3
 	github.com/stretchr/testify v1.11.0
 	gorm.io/driver/sqlite v1.6.0
@@ -20,10 +22,8 @@ require (
 	github.com/duckdb/duckdb-go-bindings/linux-amd64 v0.1.22 // indirect
 	github.com/duckdb/duckdb-go-bindings/linux-arm64 v0.1.22 // indirect
 	github.com/duckdb/duckdb-go-bindings/windows-amd64 v0.1.22 // indirect
-	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
 	github.com/goccy/go-json v0.10.5 // indirect
 	github.com/google/flatbuffers v25.9.23+incompatible // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/klauspost/compress v1.18.1 // indirect
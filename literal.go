@@ -0,0 +1,162 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// duckdbLiteral renders value as a DuckDB SQL literal, for statements that
+// do not accept bind parameters (EXECUTE, dynamic PIVOT, COPY). Unlike
+// Dialector.Explain, which only formats SQL for logs, the literal denotes
+// exactly the value go-duckdb would bind:
+//
+//   - time.Time becomes a TIMESTAMP of the same instant in UTC, as go-duckdb
+//     binds it, or a TIMESTAMP_NS when it carries nanoseconds
+//   - []byte becomes a '\xNN...'::BLOB
+//   - slices and arrays become [...] list literals of their elements
+//   - driver.Valuer types are encoded through their Value
+//
+// Maps, structs and other values without a DuckDB literal return an error.
+func duckdbLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return timestampLiteral(v)
+	case []byte:
+		return blobLiteral(v), nil
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		encoded, err := v.Value()
+		if err != nil {
+			return "", fmt.Errorf("failed to get value of %T: %w", value, err)
+		}
+		return duckdbLiteral(encoded)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return duckdbLiteral(rv.Elem().Interface())
+	case reflect.String:
+		return sqlStringLiteral(rv.String()), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return floatLiteral(rv.Float(), 32, "FLOAT"), nil
+	case reflect.Float64:
+		return floatLiteral(rv.Float(), 64, "DOUBLE"), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "NULL", nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return blobLiteral(data), nil
+		}
+		elements := make([]string, rv.Len())
+		for i := range elements {
+			element, err := duckdbLiteral(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			elements[i] = element
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("cannot encode %T as a DuckDB literal", value)
+}
+
+// timestampLiteral writes t in UTC, keeping nanoseconds when it has any
+func timestampLiteral(t time.Time) (string, error) {
+	t = t.UTC()
+	if t.Year() < 1 || t.Year() > 9999 {
+		return "", fmt.Errorf("cannot encode time %s as a DuckDB literal: year out of range", t)
+	}
+	if t.Nanosecond()%1000 != 0 {
+		return "TIMESTAMP_NS '" + t.Format("2006-01-02 15:04:05.000000000") + "'", nil
+	}
+	return "TIMESTAMP '" + t.Format("2006-01-02 15:04:05.000000") + "'", nil
+}
+
+// blobLiteral writes every byte as a \xNN escape, so no byte is mangled
+func blobLiteral(data []byte) string {
+	var b strings.Builder
+	b.Grow(len(data)*4 + 9)
+	b.WriteByte('\'')
+	for _, c := range data {
+		fmt.Fprintf(&b, `\x%02X`, c)
+	}
+	b.WriteString("'::BLOB")
+	return b.String()
+}
+
+// floatLiteral writes the shortest representation that parses back to f
+func floatLiteral(f float64, bitSize int, typeName string) string {
+	switch {
+	case math.IsNaN(f):
+		return "'nan'::" + typeName
+	case math.IsInf(f, 1):
+		return "'inf'::" + typeName
+	case math.IsInf(f, -1):
+		return "'-inf'::" + typeName
+	}
+	return "CAST(" + strconv.FormatFloat(f, 'g', -1, bitSize) + " AS " + typeName + ")"
+}
+
+// inlineVars replaces each ? placeholder in sql, outside string literals and
+// quoted identifiers, with the duckdbLiteral of the matching var.
+func inlineVars(sql string, vars []interface{}) (string, error) {
+	var b strings.Builder
+	b.Grow(len(sql))
+
+	next := 0
+	var quote byte // ' or " while inside a literal or identifier
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			// A doubled quote is an escaped one and keeps the literal open
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			if next >= len(vars) {
+				return "", fmt.Errorf("failed to inline query vars: more placeholders than the %d vars", len(vars))
+			}
+			literal, err := duckdbLiteral(vars[next])
+			if err != nil {
+				return "", fmt.Errorf("failed to inline query var %d: %w", next+1, err)
+			}
+			next++
+			b.WriteString(literal)
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	if next != len(vars) {
+		return "", fmt.Errorf("failed to inline query vars: %d placeholders for %d vars", next, len(vars))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,115 @@
+package duckdb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// migrationLockTable holds the single row AutoMigrate claims while it runs
+	migrationLockTable = "gorm_duckdb_migration_lock"
+
+	defaultMigrationLockTimeout = time.Minute
+	migrationLockPollInterval   = 50 * time.Millisecond
+)
+
+// ErrMigrationLocked is returned by AutoMigrate when Config.MigrationLock is
+// set and another instance keeps the migration lock past MigrationLockTimeout.
+var ErrMigrationLocked = errors.New("duckdb: migration lock is held by another instance")
+
+// AutoMigrate runs GORM's AutoMigrate. With Config.MigrationLock set it first
+// takes the migration lock, so concurrent instances migrate one at a time and
+// each sees the schema the previous one left behind.
+func (m Migrator) AutoMigrate(values ...interface{}) error {
+	config := migratorConfig(m.DB)
+	if config == nil || !config.MigrationLock {
+		return m.Migrator.AutoMigrate(values...)
+	}
+
+	timeout := config.MigrationLockTimeout
+	if timeout <= 0 {
+		timeout = defaultMigrationLockTimeout
+	}
+
+	holder, err := m.acquireMigrationLock(timeout)
+	if err != nil {
+		return err
+	}
+
+	migrateErr := m.Migrator.AutoMigrate(values...)
+	if err := m.releaseMigrationLock(holder); err != nil && migrateErr == nil {
+		return err
+	}
+	return migrateErr
+}
+
+// migratorConfig returns the Config of the dialector behind db, if any.
+func migratorConfig(db *gorm.DB) *Config {
+	switch dialector := db.Dialector.(type) {
+	case *Dialector:
+		return dialector.Config
+	case *extensionAwareDialector:
+		if dialector.Dialector != nil {
+			return dialector.Config
+		}
+	}
+	return nil
+}
+
+// acquireMigrationLock claims the lock row, retrying while another instance
+// holds it. A row older than timeout is deleted as abandoned before retrying.
+func (m Migrator) acquireMigrationLock(timeout time.Duration) (string, error) {
+	holder, err := migrationLockHolder()
+	if err != nil {
+		return "", err
+	}
+
+	tx := m.DB.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true})
+	deadline := time.Now().Add(timeout)
+	for {
+		// Concurrent creators and inserters fail with a conflict error rather
+		// than blocking, so every failure here is retried until the deadline.
+		err = tx.Exec("CREATE TABLE IF NOT EXISTS " + migrationLockTable +
+			" (id INTEGER PRIMARY KEY, holder VARCHAR NOT NULL, acquired_at TIMESTAMP NOT NULL)").Error
+		if err == nil {
+			err = tx.Exec("INSERT INTO "+migrationLockTable+" (id, holder, acquired_at) VALUES (1, ?, ?)",
+				holder, time.Now().UTC()).Error
+		}
+		if err == nil {
+			return holder, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("%w: waited %s: %v", ErrMigrationLocked, timeout, err)
+		}
+
+		tx.Exec("DELETE FROM "+migrationLockTable+" WHERE id = 1 AND acquired_at < ?",
+			time.Now().UTC().Add(-timeout))
+		time.Sleep(migrationLockPollInterval)
+	}
+}
+
+// releaseMigrationLock deletes the lock row if holder still owns it.
+func (m Migrator) releaseMigrationLock(holder string) error {
+	tx := m.DB.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true})
+	if err := tx.Exec("DELETE FROM "+migrationLockTable+" WHERE id = 1 AND holder = ?", holder).Error; err != nil {
+		return fmt.Errorf("failed to release migration lock: %w", err)
+	}
+	return nil
+}
+
+// migrationLockHolder identifies one AutoMigrate run across processes.
+func migrationLockHolder() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate migration lock holder: %w", err)
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), hex.EncodeToString(buf)), nil
+}
@@ -3,6 +3,7 @@ package duckdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -20,6 +21,11 @@ const (
 	notNullConstraint = " NOT NULL"
 )
 
+// ErrUnsupportedAlter is returned by AddColumn, AlterColumn and DropColumn
+// for schema changes DuckDB's ALTER TABLE cannot make; the error names the
+// column and the reason.
+var ErrUnsupportedAlter = errors.New("duckdb: unsupported ALTER TABLE")
+
 // normalizeTable splits and strips quotes from a table identifier which may be
 // schema-qualified (e.g. "schema"."table" or schema.table). Returns schema
 // (may be empty) and table name.
@@ -59,9 +65,23 @@ type Migrator struct {
 	migrator.Migrator
 }
 
-// isAutoIncrementField checks if a field is an auto-increment field
+// isAutoIncrementField checks if a field is an auto-increment field backed
+// by the seq_<table>_<column> sequence CreateTable creates. A field with an
+// explicit database default (e.g. nextval of a shared sequence) keeps it.
+// The columns of a composite primary key (e.g. a join table's two foreign
+// keys) are set by the caller, so only one tagged or inferred by GORM as
+// autoIncrement gets a sequence.
 func (m Migrator) isAutoIncrementField(field *schema.Field) bool {
-	return field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)
+	if field.DefaultValueInterface == nil && field.DefaultValue != "" && field.DefaultValue != "(-)" {
+		return false
+	}
+	if field.AutoIncrement {
+		return true
+	}
+	if field.Schema != nil && len(field.Schema.PrimaryFields) > 1 {
+		return false
+	}
+	return !field.HasDefaultValue && field.DataType == schema.Uint
 }
 
 // CurrentDatabase returns the current database name.
@@ -82,8 +102,8 @@ func (m Migrator) CurrentDatabase() (name string) {
 // FullDataTypeOf returns the full data type for a field including constraints.
 // Override FullDataTypeOf to prevent GORM from adding duplicate PRIMARY KEY clauses
 func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
-	// Get the base data type from our dialector
-	dataType := m.Dialector.DataTypeOf(field)
+	// Get the base data type, honouring GormDBDataType before our dialector
+	dataType := m.DataTypeOf(field)
 
 	expr := clause.Expr{SQL: dataType}
 
@@ -115,6 +135,7 @@ func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 			default:
 				expr.SQL = dataType
 			}
+			expr.SQL += m.defaultClause(field)
 		}
 
 		// Add NOT NULL for primary keys
@@ -134,15 +155,7 @@ func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 	}
 
 	// Handle defaults for non-primary key fields only
-	if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
-		if field.DefaultValueInterface != nil {
-			defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
-			m.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
-			expr.SQL += " DEFAULT " + m.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface)
-		} else if field.DefaultValue != "(-)" {
-			expr.SQL += " DEFAULT " + field.DefaultValue
-		}
-	}
+	expr.SQL += m.defaultClause(field)
 
 	if field.Comment != "" {
 		expr.SQL += " COMMENT '" + field.Comment + "'"
@@ -151,21 +164,154 @@ func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
 	return expr
 }
 
+// MigrateColumn changes the column's type when it differs from the field's:
+// both are compared in DuckDB's canonical spelling, so aliases such as TEXT
+// and VARCHAR(100) match. Before a change the existing values are checked to
+// survive the cast back unchanged; narrowing that would overflow or round a
+// stored value fails with ErrLossyCoercion and leaves the column as it is.
+// Primary keys, ENUM columns and columns whose types already match are
+// migrated by GORM.
+func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	if field.PrimaryKey || field.IgnoreMigration {
+		return m.Migrator.MigrateColumn(value, field, columnType)
+	}
+	if _, isEnum := registeredEnum(string(field.DataType)); isEnum {
+		return m.Migrator.MigrateColumn(value, field, columnType)
+	}
+
+	current := columnType.DatabaseTypeName()
+	target, err := m.canonicalType(strings.Split(m.DataTypeOf(field), " DEFAULT")[0])
+	if err != nil || strings.EqualFold(current, target) {
+		return m.Migrator.MigrateColumn(value, field, columnType)
+	}
+
+	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var lost int64
+		column := clause.Column{Name: field.DBName}
+		if err := m.DB.Raw(
+			"SELECT count(*) FROM ? WHERE ? IS NOT NULL AND CAST(TRY_CAST(? AS ?) AS ?) IS DISTINCT FROM ?",
+			m.CurrentTable(stmt), column, column, clause.Expr{SQL: target}, clause.Expr{SQL: current}, column,
+		).Scan(&lost).Error; err != nil {
+			return fmt.Errorf("failed to check values of column %s: %w", field.DBName, err)
+		}
+		if lost > 0 {
+			return fmt.Errorf("%w: column %s: %d existing values cannot be converted from %s to %s without loss",
+				ErrLossyCoercion, field.DBName, lost, current, target)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to migrate column type: %w", err)
+	}
+	// Every live value was checked to convert. DuckDB also casts deleted rows
+	// it has not yet vacuumed, which TRY_CAST turns into NULL instead of
+	// failing on values the check never saw.
+	return m.alterColumn(value, field.DBName, "TRY_CAST")
+}
+
+// canonicalType returns DuckDB's own spelling of dataType, as reported by
+// information_schema.columns (e.g. INT8 -> BIGINT, TEXT -> VARCHAR)
+func (m Migrator) canonicalType(dataType string) (string, error) {
+	var name string
+	if err := m.DB.Raw("SELECT typeof(CAST(NULL AS " + dataType + "))").Scan(&name).Error; err != nil {
+		return "", fmt.Errorf("failed to resolve type %s: %w", dataType, err)
+	}
+	return name, nil
+}
+
+// parseColumnDefault reduces a column_default expression to the value GORM
+// compares with a default tag: DuckDB reports DEFAULT 'open' as 'open' or
+// CAST('open' AS VARCHAR), returned as open. Other expressions, such as
+// nextval('seq') or CURRENT_TIMESTAMP, are returned as they are.
+func parseColumnDefault(columnDefault sql.NullString) sql.NullString {
+	if !columnDefault.Valid {
+		return columnDefault
+	}
+
+	value := strings.TrimSpace(columnDefault.String)
+	if upper := strings.ToUpper(value); strings.HasPrefix(upper, "CAST(") && strings.HasSuffix(upper, ")") {
+		if i := strings.LastIndex(upper, " AS "); i > len("CAST(") {
+			if literal := strings.TrimSpace(value[len("CAST("):i]); isQuotedLiteral(literal) {
+				value = literal
+			}
+		}
+	}
+	if isQuotedLiteral(value) {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
+// isQuotedLiteral reports whether s is a single-quoted SQL string literal
+func isQuotedLiteral(s string) bool {
+	return len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\''
+}
+
+// defaultClause returns the " DEFAULT ..." part of field's column
+// definition, or "" when it has no default
+func (m Migrator) defaultClause(field *schema.Field) string {
+	if !field.HasDefaultValue {
+		return ""
+	}
+	if field.DefaultValueInterface != nil {
+		defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
+		m.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
+		return " DEFAULT " + m.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface)
+	}
+	if field.DefaultValue != "" && field.DefaultValue != "(-)" {
+		return " DEFAULT " + field.DefaultValue
+	}
+	return ""
+}
+
 // AlterColumn modifies a column definition in DuckDB, handling syntax limitations.
+// DuckDB's ALTER COLUMN changes one property per statement, so the type is
+// changed first and NOT NULL set or dropped after when it differs from the
+// field's tag. DuckDB cannot change the type of a column an index depends on
+// (primary keys, unique columns); that fails with ErrUnsupportedAlter.
 func (m Migrator) AlterColumn(value interface{}, field string) error {
+	return m.alterColumn(value, field, "CAST")
+}
+
+// alterColumn is AlterColumn converting the existing values with cast, CAST
+// or TRY_CAST
+func (m Migrator) alterColumn(value interface{}, field, cast string) error {
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if field := stmt.Schema.LookUpField(field); field != nil {
 				// For ALTER COLUMN, only use the base data type without defaults
-				baseType := m.Dialector.DataTypeOf(field)
+				baseType := m.DataTypeOf(field)
 
 				// Clean the base type - remove any DEFAULT clauses
 				baseType = strings.Split(baseType, " DEFAULT")[0]
 
-				return m.DB.Exec(
-					"ALTER TABLE ? ALTER COLUMN ? TYPE ?",
-					m.CurrentTable(stmt), clause.Column{Name: field.DBName}, clause.Expr{SQL: baseType},
-				).Error
+				column := clause.Column{Name: field.DBName}
+				if err := m.DB.Exec(
+					"ALTER TABLE ? ALTER COLUMN ? TYPE ? USING "+cast+"(? AS ?)",
+					m.CurrentTable(stmt), column, clause.Expr{SQL: baseType}, column, clause.Expr{SQL: baseType},
+				).Error; err != nil {
+					return alterColumnError("change type of", field.DBName, err)
+				}
+
+				// Statements prepared against the old column type must not be reused
+				defer ClearStatementCache(m.DB)
+
+				if field.PrimaryKey {
+					return nil
+				}
+				nullable, err := m.columnNullable(stmt, field.DBName)
+				if err != nil {
+					return err
+				}
+				switch {
+				case field.NotNull && nullable:
+					err = m.DB.Exec("ALTER TABLE ? ALTER COLUMN ? SET NOT NULL", m.CurrentTable(stmt), clause.Column{Name: field.DBName}).Error
+				case !field.NotNull && !nullable:
+					err = m.DB.Exec("ALTER TABLE ? ALTER COLUMN ? DROP NOT NULL", m.CurrentTable(stmt), clause.Column{Name: field.DBName}).Error
+				}
+				if err != nil {
+					return fmt.Errorf("failed to change NOT NULL of column %s: %w", field.DBName, err)
+				}
+				return nil
 			}
 		}
 		return fmt.Errorf("failed to look up field with name: %s", field)
@@ -261,11 +407,11 @@ func (m Migrator) HasTable(value interface{}) bool {
 			tableIdentifier = fmt.Sprint(m.CurrentTable(stmt))
 		}
 
-		// Normalize table identifier to handle quoted and schema-qualified names
-		tableName := normalizeTable(tableIdentifier)
+		query, args := tableLookup(tableIdentifier)
+		tableName := args[0]
 		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.tables WHERE lower(table_name) = lower(?) AND table_type = 'BASE TABLE'",
-			tableName,
+			"SELECT count(*) FROM information_schema.tables WHERE table_type = 'BASE TABLE' AND "+query,
+			args...,
 		).Rows()
 		if err != nil {
 			return fmt.Errorf("failed to query table existence for %s: %w", tableName, err)
@@ -285,10 +431,18 @@ func (m Migrator) HasTable(value interface{}) bool {
 	return count > 0
 }
 
-// GetTables returns a list of all table names in the database.
+// GetTables returns the base tables of every attached catalog. Tables in the
+// current catalog and schema are listed by name, others qualified as
+// schema.table or catalog.schema.table, so each name can be passed back to
+// HasTable or Table.
 func (m Migrator) GetTables() (tableList []string, err error) {
-	rows, err := m.DB.Raw(
-		"SELECT table_name FROM information_schema.tables WHERE table_type = 'BASE TABLE'",
+	rows, err := m.DB.Raw(`SELECT CASE
+			WHEN table_catalog = current_database() AND table_schema = current_schema() THEN table_name
+			WHEN table_catalog = current_database() THEN table_schema || '.' || table_name
+			ELSE table_catalog || '.' || table_schema || '.' || table_name
+		END
+		FROM information_schema.tables WHERE table_type = 'BASE TABLE'
+		ORDER BY table_catalog <> current_database(), table_catalog, table_schema, table_name`,
 	).Rows()
 	if err != nil {
 		return nil, fmt.Errorf("failed to query information_schema tables: %w", err)
@@ -307,6 +461,28 @@ func (m Migrator) GetTables() (tableList []string, err error) {
 	return tableList, nil
 }
 
+// tableLookup returns an information_schema.tables condition matching a
+// table identifier and its arguments, the table name first. A bare name is
+// looked up in the current catalog and schema (or among temporary tables),
+// "x.table" in schema x of the current catalog or the main schema of an
+// attached catalog x, and "catalog.schema.table" exactly.
+func tableLookup(identifier string) (string, []interface{}) {
+	parts := strings.Split(strings.NewReplacer(`"`, "", "`", "").Replace(identifier), ".")
+	table := parts[len(parts)-1]
+
+	switch len(parts) {
+	case 1:
+		return "lower(table_name) = lower(?) AND ((table_catalog = current_database() AND table_schema = current_schema()) OR table_catalog = 'temp')",
+			[]interface{}{table}
+	case 2:
+		return "lower(table_name) = lower(?) AND ((table_catalog = current_database() AND lower(table_schema) = lower(?)) OR (lower(table_catalog) = lower(?) AND table_schema = 'main'))",
+			[]interface{}{table, parts[0], parts[0]}
+	default:
+		return "lower(table_name) = lower(?) AND lower(table_catalog) = lower(?) AND lower(table_schema) = lower(?)",
+			[]interface{}{table, parts[len(parts)-3], parts[len(parts)-2]}
+	}
+}
+
 // HasColumn checks if a column exists in the database table.
 func (m Migrator) HasColumn(value interface{}, field string) bool {
 	var count int64
@@ -370,7 +546,7 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 		}
 		tableName := normalizeTable(tableIdentifier)
 		rows, err := m.DB.Raw(
-			"SELECT count(*) FROM information_schema.statistics WHERE lower(table_name) = lower(?) AND lower(index_name) = lower(?)",
+			"SELECT count(*) FROM duckdb_indexes() WHERE lower(table_name) = lower(?) AND lower(index_name) = lower(?)",
 			tableName, name,
 		).Rows()
 		if err != nil {
@@ -481,10 +657,18 @@ func (m Migrator) GetTypeAliases(databaseTypeName string) []string {
 		"timestamp": {"time"},
 	}
 
+	// Named ENUM columns report their values, not the type name
+	if strings.HasPrefix(databaseTypeName, "enum(") {
+		return registeredEnumsMatching(databaseTypeName)
+	}
+
 	return aliases[databaseTypeName]
 }
 
-// ColumnTypes returns comprehensive column type information for the given value
+// ColumnTypes returns comprehensive column type information for the given value.
+// Columns whose default draws from a sequence, such as the
+// DEFAULT nextval('seq_<table>_<column>') CreateTable emits for auto-increment
+// keys, report AutoIncrement true with the nextval call as their DefaultValue.
 func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	var columnTypes []gorm.ColumnType
 
@@ -532,7 +716,7 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 				CASE WHEN c.is_nullable = 'YES' THEN true ELSE false END as nullable,
 				c.column_default,
 				COALESCE(pk.is_primary_key, false) as is_primary_key,
-				CASE WHEN c.column_default LIKE '%nextval%' OR c.column_default LIKE '%seq_%' THEN true ELSE false END as is_auto_increment,
+				CASE WHEN c.column_default LIKE 'nextval(%' THEN true ELSE false END as is_auto_increment,
 				c.character_maximum_length,
 				c.numeric_precision,
 				c.numeric_scale,
@@ -595,7 +779,7 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 				AutoIncrementValue: sql.NullBool{Bool: isAutoIncrement, Valid: true},
 				UniqueValue:        sql.NullBool{Bool: isUnique, Valid: true},
 				CommentValue:       sql.NullString{String: columnComment, Valid: columnComment != ""},
-				DefaultValueValue:  columnDefault,
+				DefaultValueValue:  parseColumnDefault(columnDefault),
 				ScanTypeValue:      reflect.TypeOf(""), // Default to string type for safety
 			}
 
@@ -623,12 +807,21 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 				}
 			}
 
+			// migrator.ColumnType.Length falls back to the nil SQLColumnType
+			// when LengthValue is unset, so report 0 for types without one
+			if !columnType.LengthValue.Valid {
+				columnType.LengthValue = sql.NullInt64{Valid: true}
+			}
+
 			// Set decimal size information
 			if numericPrecision.Valid {
 				columnType.DecimalSizeValue = numericPrecision
 				if numericScale.Valid {
 					columnType.ScaleValue = numericScale
 				}
+			} else {
+				// Like Length, DecimalSize would fall back to the nil SQLColumnType
+				columnType.DecimalSizeValue = sql.NullInt64{Valid: true}
 			}
 
 			columnTypes = append(columnTypes, columnType)
@@ -744,9 +937,71 @@ func (idx Index) Option() string {
 func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	var indexes []gorm.Index
 
-	err := m.RunWithValue(value, func(_ *gorm.Statement) error {
-		// DuckDB may not have complete information_schema.statistics support
-		// For now, return empty indexes to avoid errors
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		tableIdentifier := stmt.Table
+		if stmt.Schema != nil && stmt.Schema.Table != "" {
+			tableIdentifier = stmt.Schema.Table
+		}
+		tableName := normalizeTable(tableIdentifier)
+
+		// PRIMARY KEY and UNIQUE constraints are backed by indexes DuckDB
+		// does not list in duckdb_indexes()
+		var constraintColumns []struct {
+			ConstraintIndex int
+			ConstraintType  string
+			ColumnName      string
+		}
+		if err := m.DB.Raw(`SELECT constraint_index, constraint_type,
+				unnest(constraint_column_names) AS column_name, unnest(range(len(constraint_column_names))) AS position
+			FROM duckdb_constraints()
+			WHERE lower(table_name) = lower(?) AND database_name = current_database() AND schema_name = current_schema()
+				AND constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+			ORDER BY constraint_index, position`, tableName).Scan(&constraintColumns).Error; err != nil {
+			return fmt.Errorf("failed to list constraints of %s: %w", tableName, err)
+		}
+		for i := 0; i < len(constraintColumns); {
+			constraint := constraintColumns[i]
+			var columns []string
+			for ; i < len(constraintColumns) && constraintColumns[i].ConstraintIndex == constraint.ConstraintIndex; i++ {
+				columns = append(columns, constraintColumns[i].ColumnName)
+			}
+
+			isPrimaryKey := constraint.ConstraintType == "PRIMARY KEY"
+			name := tableName + "_" + strings.Join(columns, "_") + "_key"
+			if isPrimaryKey {
+				name = tableName + "_pkey"
+			}
+			indexes = append(indexes, &migrator.Index{
+				TableName:       tableName,
+				NameValue:       name,
+				ColumnList:      columns,
+				PrimaryKeyValue: sql.NullBool{Bool: isPrimaryKey, Valid: true},
+				UniqueValue:     sql.NullBool{Bool: true, Valid: true},
+			})
+		}
+
+		var created []struct {
+			IndexName string
+			IsUnique  bool
+			IsPrimary bool
+			SQL       string
+		}
+		if err := m.DB.Raw(`SELECT index_name, is_unique, is_primary, sql
+			FROM duckdb_indexes()
+			WHERE lower(table_name) = lower(?) AND database_name = current_database() AND schema_name = current_schema()
+			ORDER BY index_name`, tableName).Scan(&created).Error; err != nil {
+			return fmt.Errorf("failed to list indexes of %s: %w", tableName, err)
+		}
+		for _, index := range created {
+			unique := index.IsUnique || strings.HasPrefix(strings.ToUpper(strings.TrimSpace(index.SQL)), "CREATE UNIQUE")
+			indexes = append(indexes, &migrator.Index{
+				TableName:       tableName,
+				NameValue:       index.IndexName,
+				ColumnList:      indexColumns(index.SQL),
+				PrimaryKeyValue: sql.NullBool{Bool: index.IsPrimary, Valid: true},
+				UniqueValue:     sql.NullBool{Bool: unique, Valid: true},
+			})
+		}
 		return nil
 	})
 
@@ -756,6 +1011,54 @@ func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	return indexes, nil
 }
 
+// indexColumns returns the key columns, in order, of a CREATE INDEX statement
+// such as CREATE UNIQUE INDEX idx ON t ("a", b). Expression keys are
+// returned as written.
+func indexColumns(ddl string) []string {
+	upper := strings.ToUpper(ddl)
+	on := strings.Index(upper, " ON ")
+	if on < 0 {
+		return nil
+	}
+	open := strings.IndexByte(ddl[on:], '(')
+	if open < 0 {
+		return nil
+	}
+
+	var columns []string
+	var current strings.Builder
+	depth, quoted := 0, false
+	for _, r := range ddl[on+open+1:] {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')' && depth == 0:
+			return append(columns, unquoteIdentifier(current.String()))
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			columns = append(columns, unquoteIdentifier(current.String()))
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return columns
+}
+
+// unquoteIdentifier trims s and removes the double quotes around a quoted
+// identifier
+func unquoteIdentifier(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
+
 // BuildIndexOptions builds index options for DuckDB
 func (m Migrator) BuildIndexOptions(opts []schema.IndexOption, stmt *gorm.Statement) (results []interface{}) {
 	for _, opt := range opts {
@@ -788,10 +1091,17 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				return fmt.Errorf("failed to get underlying database: %w", err)
 			}
 
+			// Step 0: Create registered ENUM types the columns use
+			if stmt.Schema != nil {
+				if err := m.createEnumTypes(stmt.Schema.Fields); err != nil {
+					return err
+				}
+			}
+
 			// Step 1: Create sequences for auto-increment fields
 			if stmt.Schema != nil {
 				for _, field := range stmt.Schema.Fields {
-					if field.PrimaryKey && (field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)) {
+					if field.PrimaryKey && m.isAutoIncrementField(field) {
 						sequenceName := "seq_" + strings.ToLower(stmt.Schema.Table) + "_" + strings.ToLower(field.DBName)
 					createSeqSQL := fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START 1", sequenceName)
 					_, err := sqlDB.ExecContext(context.Background(), createSeqSQL)
@@ -814,11 +1124,16 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 			var columns []string
 			var primaryKeys []string
 
-			for _, field := range stmt.Schema.Fields {
+			// Only fields backed by a column; relations (e.g. many2many) have no DBName
+			for _, dbName := range stmt.Schema.DBNames {
+				field := stmt.Schema.FieldsByDBName[dbName]
+				if field.IgnoreMigration {
+					continue
+				}
 				columnDef := fmt.Sprintf(`"%s"`, field.DBName)
 
 				// Add data type
-				columnDef += " " + m.Dialector.DataTypeOf(field)
+				columnDef += " " + m.DataTypeOf(field)
 
 				// Add constraints
 				if field.NotNull {
@@ -832,9 +1147,11 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				}
 
 				// Handle auto-increment by setting default to nextval
-				if field.PrimaryKey && (field.AutoIncrement || (!field.HasDefaultValue && field.DataType == schema.Uint)) {
+				if field.PrimaryKey && m.isAutoIncrementField(field) {
 					sequenceName := "seq_" + strings.ToLower(stmt.Schema.Table) + "_" + strings.ToLower(field.DBName)
 					columnDef += fmt.Sprintf(" DEFAULT nextval('%s')", sequenceName)
+				} else {
+					columnDef += m.defaultClause(field)
 				}
 
 				columns = append(columns, columnDef)
@@ -856,6 +1173,18 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				return fmt.Errorf("failed to create table %s: %w", tableName, err)
 			}
 
+			// Step 4: Create unique indexes, including composite ones from
+			// fields sharing a uniqueIndex:name tag, so duplicates are rejected
+			// from the first insert
+			for _, idx := range stmt.Schema.ParseIndexes() {
+				if idx.Class != "UNIQUE" {
+					continue
+				}
+				if err := m.CreateIndex(value, idx.Name); err != nil {
+					return fmt.Errorf("failed to create unique index %s: %w", idx.Name, err)
+				}
+			}
+
 			return nil
 		}); err != nil {
 			return fmt.Errorf("failed to create table for value: %w", err)
@@ -863,3 +1192,212 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 	}
 	return nil
 }
+
+// AddColumn adds the field's column to an existing table, creating the
+// registered ENUM type it uses first, if any. DuckDB cannot add a column
+// with constraints, so it is added with its type and default only; NOT NULL
+// is then set with ALTER COLUMN, which fails when existing rows are left
+// NULL (give the field a default), and a unique column gets a unique index.
+// A primary key column cannot be added and fails with ErrUnsupportedAlter.
+func (m Migrator) AddColumn(value interface{}, name string) error {
+	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return m.Migrator.AddColumn(value, name)
+		}
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return fmt.Errorf("failed to look up field with name: %s", name)
+		}
+		if field.PrimaryKey {
+			return fmt.Errorf("%w: cannot add column %s: DuckDB cannot add a PRIMARY KEY column to an existing table",
+				ErrUnsupportedAlter, field.DBName)
+		}
+		if err := m.createEnumTypes([]*schema.Field{field}); err != nil {
+			return err
+		}
+
+		table := m.CurrentTable(stmt)
+		column := clause.Column{Name: field.DBName}
+		if err := m.DB.Exec("ALTER TABLE ? ADD COLUMN ? ?",
+			table, column, clause.Expr{SQL: m.DataTypeOf(field) + m.defaultClause(field)}).Error; err != nil {
+			return err
+		}
+		defer ClearStatementCache(m.DB)
+
+		if field.NotNull {
+			if err := m.DB.Exec("ALTER TABLE ? ALTER COLUMN ? SET NOT NULL", table, column).Error; err != nil {
+				return fmt.Errorf("column %s is NOT NULL without a default, but existing rows have no value for it: %w", field.DBName, err)
+			}
+		}
+		if field.Unique {
+			return m.createUniqueIndex(stmt, field)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to add column %s: %w", name, err)
+	}
+	return nil
+}
+
+// MigrateColumnUnique backs a column newly tagged unique with a unique index,
+// as DuckDB cannot add a UNIQUE constraint to an existing table. Other
+// changes are left to GORM.
+func (m Migrator) MigrateColumnUnique(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	unique, ok := columnType.Unique()
+	if !ok || field.PrimaryKey || !field.Unique || unique {
+		return m.Migrator.MigrateColumnUnique(value, field, columnType)
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.createUniqueIndex(stmt, field)
+	})
+}
+
+// createUniqueIndex creates the unique index standing in for field's UNIQUE
+// constraint, named like GORM's constraint (uni_<table>_<column>)
+func (m Migrator) createUniqueIndex(stmt *gorm.Statement, field *schema.Field) error {
+	indexName := m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)
+	if err := m.DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS ? ON ? (?)",
+		clause.Column{Name: indexName}, m.CurrentTable(stmt), clause.Column{Name: field.DBName}).Error; err != nil {
+		return fmt.Errorf("failed to create unique index %s: %w", indexName, err)
+	}
+	return nil
+}
+
+// DropColumn drops the field's column. DuckDB refuses to drop a column an
+// index depends on; that fails with ErrUnsupportedAlter naming the column.
+// It also refuses while an index covers a later column: CREATE INDEX indexes
+// are then dropped and recreated around the drop, and a PRIMARY KEY or
+// UNIQUE constraint on a later column fails with ErrUnsupportedAlter.
+func (m Migrator) DropColumn(value interface{}, name string) error {
+	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(name); field != nil {
+				name = field.DBName
+			}
+		}
+		err := m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", m.CurrentTable(stmt), clause.Column{Name: name}).Error
+		if err != nil && isIndexAfterColumnError(err) {
+			err = m.dropColumnRebuildingIndexes(stmt, name)
+		}
+		if err != nil {
+			return alterColumnError("drop", name, err)
+		}
+		ClearStatementCache(m.DB)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to drop column: %w", err)
+	}
+	return nil
+}
+
+// isIndexAfterColumnError reports DuckDB's refusal to drop a column while an
+// index covers a column after it, whose position the drop would shift
+func isIndexAfterColumnError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "depends on a column after it")
+}
+
+// dropColumnRebuildingIndexes drops the table's CREATE INDEX indexes, drops
+// column and recreates the indexes from their DDL. DuckDB keeps a dropped
+// index until its transaction commits, so the steps cannot share one
+// transaction: the indexes are recreated if the column cannot be dropped,
+// and writes made meanwhile are not checked by dropped unique indexes.
+// Indexes backing PRIMARY KEY and UNIQUE constraints cannot be dropped and
+// still block the column.
+func (m Migrator) dropColumnRebuildingIndexes(stmt *gorm.Statement, column string) error {
+	tableIdentifier := stmt.Table
+	if stmt.Schema != nil && stmt.Schema.Table != "" {
+		tableIdentifier = stmt.Schema.Table
+	}
+
+	var indexes []struct {
+		IndexName string
+		SQL       string
+	}
+	if err := m.DB.Raw(`SELECT index_name, sql FROM duckdb_indexes()
+		WHERE lower(table_name) = lower(?) AND database_name = current_database() AND schema_name = current_schema()
+			AND sql IS NOT NULL
+		ORDER BY index_oid`, normalizeTable(tableIdentifier)).Scan(&indexes).Error; err != nil {
+		return fmt.Errorf("failed to list indexes: %w", err)
+	}
+
+	recreate := func(count int) error {
+		for _, index := range indexes[:count] {
+			if err := m.DB.Exec(index.SQL).Error; err != nil {
+				return fmt.Errorf("failed to recreate index %s: %w", index.IndexName, err)
+			}
+		}
+		return nil
+	}
+
+	for i, index := range indexes {
+		if err := m.DB.Exec("DROP INDEX ?", clause.Table{Name: index.IndexName}).Error; err != nil {
+			return errors.Join(fmt.Errorf("failed to drop index %s: %w", index.IndexName, err), recreate(i))
+		}
+	}
+	if err := m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", m.CurrentTable(stmt), clause.Column{Name: column}).Error; err != nil {
+		return errors.Join(err, recreate(len(indexes)))
+	}
+	return recreate(len(indexes))
+}
+
+// columnNullable reports whether column of stmt's table accepts NULL
+func (m Migrator) columnNullable(stmt *gorm.Statement, column string) (bool, error) {
+	table := stmt.Table
+	if stmt.Schema != nil && stmt.Schema.Table != "" {
+		table = stmt.Schema.Table
+	}
+
+	var isNullable string
+	if err := m.DB.Raw(
+		"SELECT is_nullable FROM information_schema.columns WHERE lower(table_name) = lower(?) AND lower(column_name) = lower(?) AND table_schema = current_schema()",
+		normalizeTable(table), column,
+	).Scan(&isNullable).Error; err != nil {
+		return false, fmt.Errorf("failed to look up column %s: %w", column, err)
+	}
+	return isNullable != "NO", nil
+}
+
+// alterColumnError wraps an ALTER TABLE failure on column, reporting DuckDB's
+// dependent-index restrictions as ErrUnsupportedAlter
+func alterColumnError(action, column string, err error) error {
+	if strings.Contains(strings.ToLower(err.Error()), "depends on it") {
+		return fmt.Errorf("%w: cannot %s column %s: an index depends on it, drop the index first: %v",
+			ErrUnsupportedAlter, action, column, err)
+	}
+	if isIndexAfterColumnError(err) {
+		return fmt.Errorf("%w: cannot %s column %s: a PRIMARY KEY or UNIQUE constraint covers a column after it: %v",
+			ErrUnsupportedAlter, action, column, err)
+	}
+	return fmt.Errorf("failed to %s column %s: %w", action, column, err)
+}
+
+// createEnumTypes creates the ENUM types registered with RegisterEnum that
+// fields use and the current schema does not have yet
+func (m Migrator) createEnumTypes(fields []*schema.Field) error {
+	for _, field := range fields {
+		name := string(field.DataType)
+		values, ok := registeredEnum(name)
+		if !ok {
+			continue
+		}
+
+		var existing int64
+		if err := m.DB.Raw(`SELECT count(*) FROM duckdb_types()
+			WHERE lower(type_name) = lower(?) AND database_name = current_database() AND schema_name = current_schema()`,
+			name).Scan(&existing).Error; err != nil {
+			return fmt.Errorf("failed to look up type %s: %w", name, err)
+		}
+		if existing > 0 {
+			continue
+		}
+
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = sqlStringLiteral(value)
+		}
+		if err := m.DB.Exec("CREATE TYPE " + m.DB.Statement.Quote(name) + " AS ENUM (" + strings.Join(literals, ", ") + ")").Error; err != nil {
+			return fmt.Errorf("failed to create type %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 package duckdb_test
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -332,6 +337,30 @@ func TestMigrator_GetTables(t *testing.T) {
 	assert.Contains(t, tables, "migration_test_posts")
 }
 
+func TestMigrator_AttachedCatalogTables(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	require.NoError(t, db.Exec("ATTACH ':memory:' AS archive").Error)
+	require.NoError(t, db.Exec("CREATE TABLE archive.old_orders (id INTEGER)").Error)
+	require.NoError(t, db.Exec("CREATE SCHEMA staging").Error)
+	require.NoError(t, db.Exec("CREATE TABLE staging.imports (id INTEGER)").Error)
+
+	assert.True(t, migrator.HasTable("archive.old_orders"))
+	assert.True(t, migrator.HasTable("archive.main.old_orders"))
+	assert.True(t, migrator.HasTable(`"archive"."old_orders"`))
+	assert.False(t, migrator.HasTable("old_orders"), "bare names resolve in the current catalog")
+	assert.True(t, migrator.HasTable("staging.imports"))
+	assert.False(t, migrator.HasTable("imports"))
+
+	tables, err := migrator.GetTables()
+	require.NoError(t, err)
+	assert.Contains(t, tables, "archive.main.old_orders")
+	assert.Contains(t, tables, "staging.imports")
+	for _, table := range tables {
+		assert.True(t, migrator.HasTable(table), "%s should round-trip through HasTable", table)
+	}
+}
+
 func TestMigrator_FullDataTypeOf(t *testing.T) {
 	_, migrator := setupMigratorTestDB(t)
 
@@ -440,3 +469,553 @@ func TestMigrator_GetTypeAliases(t *testing.T) {
 	}
 	// The main test is that the method doesn't panic
 }
+
+func TestMigrator_ColumnTypesAutoIncrement(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestUser{}))
+
+	columnTypes, err := migrator.ColumnTypes(&TestUser{})
+	require.NoError(t, err)
+
+	byName := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, columnType := range columnTypes {
+		byName[columnType.Name()] = columnType
+	}
+
+	id := byName["id"]
+	require.NotNil(t, id)
+	autoIncrement, ok := id.AutoIncrement()
+	assert.True(t, ok)
+	assert.True(t, autoIncrement)
+	defaultValue, ok := id.DefaultValue()
+	assert.True(t, ok)
+	assert.Equal(t, "nextval('seq_test_users_id')", defaultValue)
+
+	autoIncrement, _ = byName["name"].AutoIncrement()
+	assert.False(t, autoIncrement)
+
+	t.Run("PlainDefaultIsNotAutoIncrement", func(t *testing.T) {
+		require.NoError(t, db.Exec("CREATE TABLE sequel_notes (id INTEGER, title VARCHAR DEFAULT 'seq_notes')").Error)
+
+		columnTypes, err := migrator.ColumnTypes("sequel_notes")
+		require.NoError(t, err)
+		require.Len(t, columnTypes, 2)
+
+		for _, columnType := range columnTypes {
+			autoIncrement, ok := columnType.AutoIncrement()
+			assert.True(t, ok)
+			assert.False(t, autoIncrement, columnType.Name())
+		}
+	})
+}
+
+type CatalogProduct struct {
+	ID     uint    `gorm:"primaryKey"`
+	Name   string  `gorm:"size:100;not null"`
+	Status string  `gorm:"default:'draft'"`
+	Stock  int     `gorm:"not null;default:5"`
+	Price  float64 `gorm:"type:DECIMAL(10,2)"`
+	Note   *string
+}
+
+func TestMigrator_ColumnTypesMetadata(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&CatalogProduct{}))
+
+	columnTypes, err := migrator.ColumnTypes(&CatalogProduct{})
+	require.NoError(t, err)
+	require.Len(t, columnTypes, 6)
+
+	byName := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, columnType := range columnTypes {
+		byName[columnType.Name()] = columnType
+	}
+
+	nullable := func(name string) bool {
+		t.Helper()
+		value, ok := byName[name].Nullable()
+		require.True(t, ok, name)
+		return value
+	}
+	assert.False(t, nullable("id"))
+	assert.False(t, nullable("name"))
+	assert.True(t, nullable("status"))
+	assert.False(t, nullable("stock"))
+	assert.True(t, nullable("note"))
+
+	length, ok := byName["name"].Length()
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), length)
+
+	defaultValue, ok := byName["status"].DefaultValue()
+	assert.True(t, ok)
+	assert.Equal(t, "draft", defaultValue)
+
+	defaultValue, ok = byName["stock"].DefaultValue()
+	assert.True(t, ok)
+	assert.Equal(t, "5", defaultValue)
+
+	_, ok = byName["note"].DefaultValue()
+	assert.False(t, ok)
+
+	precision, scale, ok := byName["price"].DecimalSize()
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), precision)
+	assert.Equal(t, int64(2), scale)
+
+	// The defaults match the model, so migrating again changes nothing
+	require.NoError(t, db.AutoMigrate(&CatalogProduct{}))
+}
+
+// CurrencyAmount binds as a string, like decimal libraries such as
+// shopspring/decimal
+type CurrencyAmount struct{ digits string }
+
+func (c CurrencyAmount) Value() (driver.Value, error) { return c.digits, nil }
+
+func (c *CurrencyAmount) Scan(value interface{}) error {
+	c.digits = fmt.Sprint(value)
+	return nil
+}
+
+type DecimalLedger struct {
+	ID      uint               `gorm:"primaryKey"`
+	Amount  CurrencyAmount     `gorm:"precision:10;scale:2"`
+	Rate    duckdb.DecimalType `gorm:"precision:8;scale:4"`
+	Fee     duckdb.DecimalType `gorm:"type:decimal(12,3)"`
+	Balance duckdb.DecimalType
+	Ratio   float64 `gorm:"precision:6;scale:3"`
+}
+
+func TestMigrator_DecimalPrecision(t *testing.T) {
+	db, _ := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&DecimalLedger{}))
+
+	var columns []struct {
+		ColumnName string
+		DataType   string
+	}
+	require.NoError(t, db.Raw("SELECT column_name, data_type FROM information_schema.columns WHERE table_name = ?", "decimal_ledgers").Scan(&columns).Error)
+
+	types := make(map[string]string, len(columns))
+	for _, column := range columns {
+		types[column.ColumnName] = column.DataType
+	}
+	assert.Equal(t, "DECIMAL(10,2)", types["amount"])
+	assert.Equal(t, "DECIMAL(8,4)", types["rate"])
+	assert.Equal(t, "DECIMAL(12,3)", types["fee"])
+	assert.Equal(t, "DECIMAL(18,6)", types["balance"])
+	assert.Equal(t, "DECIMAL(6,3)", types["ratio"])
+
+	require.NoError(t, db.Create(&DecimalLedger{Amount: CurrencyAmount{"1234.56"}, Ratio: 0.125}).Error)
+	var ledger DecimalLedger
+	require.NoError(t, db.First(&ledger).Error)
+	assert.Equal(t, CurrencyAmount{"1234.56"}, ledger.Amount)
+}
+
+type EnumTicket struct {
+	ID       uint            `gorm:"primaryKey"`
+	Priority duckdb.ENUMType `gorm:"type:ticket_priority"`
+	Channel  string          `gorm:"type:ticket_channel"`
+}
+
+type EnumTicketV2 struct {
+	ID       uint            `gorm:"primaryKey"`
+	Priority duckdb.ENUMType `gorm:"type:ticket_priority"`
+	Channel  string          `gorm:"type:ticket_channel"`
+	Status   string          `gorm:"type:ticket_status"`
+}
+
+func (EnumTicketV2) TableName() string { return "enum_tickets" }
+
+func TestMigrator_EnumTypes(t *testing.T) {
+	db, _ := setupMigratorTestDB(t)
+	duckdb.RegisterEnum("ticket_priority", "low", "normal", "high", "urgent")
+	duckdb.RegisterEnum("ticket_channel", "email", "phone", "chat")
+	duckdb.RegisterEnum("ticket_status", "open", "closed")
+
+	require.NoError(t, db.AutoMigrate(&EnumTicket{}))
+	// Idempotent: the types and table already exist
+	require.NoError(t, db.AutoMigrate(&EnumTicket{}))
+
+	var enumTypes []string
+	require.NoError(t, db.Raw("SELECT type_name FROM duckdb_types() WHERE logical_type = 'ENUM' AND type_name LIKE 'ticket_%' ORDER BY type_name").Scan(&enumTypes).Error)
+	assert.Equal(t, []string{"ticket_channel", "ticket_priority"}, enumTypes)
+
+	require.NoError(t, db.Create(&EnumTicket{Priority: duckdb.NewEnum("ticket_priority", nil, "high"), Channel: "chat"}).Error)
+	require.NoError(t, db.Create(&EnumTicket{Priority: duckdb.NewEnum("ticket_priority", nil, "low"), Channel: "email"}).Error)
+	assert.Error(t, db.Create(&EnumTicket{Priority: duckdb.NewEnum("ticket_priority", nil, "low"), Channel: "fax"}).Error)
+
+	var channels []string
+	require.NoError(t, db.Model(&EnumTicket{}).Where("priority > ?", duckdb.NewEnum("ticket_priority", nil, "normal")).Pluck("channel", &channels).Error)
+	assert.Equal(t, []string{"chat"}, channels)
+
+	t.Run("AddColumn", func(t *testing.T) {
+		require.NoError(t, db.AutoMigrate(&EnumTicketV2{}))
+		require.NoError(t, db.Model(&EnumTicketV2{}).Where("1 = 1").Update("status", "open").Error)
+
+		var statuses []string
+		require.NoError(t, db.Model(&EnumTicketV2{}).Pluck("status", &statuses).Error)
+		assert.Equal(t, []string{"open", "open"}, statuses)
+	})
+}
+
+type CompositeMembership struct {
+	ID      uint   `gorm:"primaryKey"`
+	TeamID  uint   `gorm:"uniqueIndex:idx_team_member"`
+	UserID  uint   `gorm:"uniqueIndex:idx_team_member"`
+	Role    string `gorm:"size:50"`
+	Comment string
+}
+
+func TestMigrator_CompositeUniqueIndex(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	require.NoError(t, db.AutoMigrate(&CompositeMembership{}))
+	// Idempotent: the index already exists
+	require.NoError(t, db.AutoMigrate(&CompositeMembership{}))
+	assert.True(t, migrator.HasIndex(&CompositeMembership{}, "idx_team_member"))
+
+	var isUnique bool
+	require.NoError(t, db.Raw("SELECT is_unique FROM duckdb_indexes() WHERE index_name = 'idx_team_member'").Scan(&isUnique).Error)
+	assert.True(t, isUnique)
+
+	require.NoError(t, db.Create(&CompositeMembership{TeamID: 1, UserID: 1, Role: "owner"}).Error)
+	require.NoError(t, db.Create(&CompositeMembership{TeamID: 1, UserID: 2, Role: "member"}).Error)
+	require.NoError(t, db.Create(&CompositeMembership{TeamID: 2, UserID: 1, Role: "member"}).Error)
+
+	err := db.Create(&CompositeMembership{TeamID: 1, UserID: 1, Role: "member"}).Error
+	assert.Error(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&CompositeMembership{}).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+}
+
+type UserRoleGrant struct {
+	UserID    uint `gorm:"primaryKey"`
+	RoleID    uint `gorm:"primaryKey"`
+	GrantedBy string
+}
+
+type DocumentRevision struct {
+	ID      uint `gorm:"primaryKey"`
+	Version int  `gorm:"primaryKey"`
+	Body    string
+}
+
+func TestMigrator_CompositePrimaryKey(t *testing.T) {
+	db, _ := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&UserRoleGrant{}, &DocumentRevision{}))
+
+	var keyColumns []string
+	require.NoError(t, db.Raw(`SELECT unnest(constraint_column_names) FROM duckdb_constraints()
+		WHERE table_name = 'user_role_grants' AND constraint_type = 'PRIMARY KEY'`).Scan(&keyColumns).Error)
+	assert.Equal(t, []string{"user_id", "role_id"}, keyColumns)
+
+	// Neither key column of the join table is generated
+	var sequences int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM duckdb_sequences() WHERE sequence_name LIKE 'seq_user_role_grants_%'").Scan(&sequences).Error)
+	assert.Zero(t, sequences)
+
+	require.NoError(t, db.Create(&UserRoleGrant{UserID: 1, RoleID: 1, GrantedBy: "root"}).Error)
+	require.NoError(t, db.Create(&UserRoleGrant{UserID: 1, RoleID: 2, GrantedBy: "root"}).Error)
+	require.NoError(t, db.Create(&UserRoleGrant{UserID: 2, RoleID: 1, GrantedBy: "admin"}).Error)
+	err := db.Create(&UserRoleGrant{UserID: 1, RoleID: 2, GrantedBy: "admin"}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+	var grant UserRoleGrant
+	require.NoError(t, db.First(&grant, "user_id = ? AND role_id = ?", 2, 1).Error)
+	assert.Equal(t, "admin", grant.GrantedBy)
+
+	t.Run("GeneratedIDWithVersion", func(t *testing.T) {
+		// GORM treats ID as auto-increment: a zero ID is generated and
+		// returned, a set one is inserted as given
+		first := DocumentRevision{Version: 1, Body: "draft"}
+		require.NoError(t, db.Create(&first).Error)
+		require.NotZero(t, first.ID)
+
+		second := DocumentRevision{ID: first.ID, Version: 2, Body: "final"}
+		require.NoError(t, db.Create(&second).Error)
+		assert.Equal(t, first.ID, second.ID)
+
+		var revisions []DocumentRevision
+		require.NoError(t, db.Where("id = ?", first.ID).Order("version").Find(&revisions).Error)
+		assert.Equal(t, []DocumentRevision{first, second}, revisions)
+	})
+}
+
+type StockItemV1 struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func (StockItemV1) TableName() string { return "stock_items" }
+
+type StockItemV2 struct {
+	ID       uint `gorm:"primaryKey"`
+	Name     string
+	Quantity int    `gorm:"not null;default:0"`
+	Location string `gorm:"default:'warehouse'"`
+	Barcode  string `gorm:"unique"`
+}
+
+func (StockItemV2) TableName() string { return "stock_items" }
+
+type StockItemV3 struct {
+	StockItemV2
+	Supplier string `gorm:"not null"`
+}
+
+func (StockItemV3) TableName() string { return "stock_items" }
+
+type StockItemV4 struct {
+	StockItemV2
+	LegacyCode uint `gorm:"primaryKey"`
+}
+
+func (StockItemV4) TableName() string { return "stock_items" }
+
+func TestMigrator_IncrementalAutoMigrate(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	require.NoError(t, db.AutoMigrate(&StockItemV1{}))
+	require.NoError(t, db.Create(&[]StockItemV1{{Name: "bolt"}, {Name: "nut"}}).Error)
+
+	// New fields on a populated table are added in place
+	require.NoError(t, db.AutoMigrate(&StockItemV2{}))
+	for _, column := range []string{"quantity", "location", "barcode"} {
+		assert.True(t, migrator.HasColumn(&StockItemV2{}, column), column)
+	}
+
+	var items []StockItemV2
+	require.NoError(t, db.Order("id").Find(&items).Error)
+	require.Len(t, items, 2)
+	assert.Equal(t, "bolt", items[0].Name)
+	assert.Equal(t, 0, items[0].Quantity)
+	assert.Equal(t, "warehouse", items[1].Location)
+
+	columnTypes, err := migrator.ColumnTypes(&StockItemV2{})
+	require.NoError(t, err)
+	for _, columnType := range columnTypes {
+		if columnType.Name() == "quantity" {
+			nullable, ok := columnType.Nullable()
+			assert.True(t, ok)
+			assert.False(t, nullable, "quantity is NOT NULL")
+		}
+	}
+
+	require.NoError(t, db.Create(&StockItemV2{Name: "washer", Barcode: "W-1"}).Error)
+	assert.ErrorIs(t, db.Create(&StockItemV2{Name: "washer", Barcode: "W-1"}).Error, gorm.ErrDuplicatedKey)
+
+	// Idempotent once the table matches the model
+	require.NoError(t, db.AutoMigrate(&StockItemV2{}))
+
+	t.Run("NotNullWithoutDefault", func(t *testing.T) {
+		err := db.AutoMigrate(&StockItemV3{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "supplier")
+	})
+
+	t.Run("PrimaryKeyColumn", func(t *testing.T) {
+		err := migrator.AddColumn(&StockItemV4{}, "LegacyCode")
+		assert.ErrorIs(t, err, duckdb.ErrUnsupportedAlter)
+		assert.Contains(t, err.Error(), "legacy_code")
+	})
+
+	t.Run("DropColumn", func(t *testing.T) {
+		require.NoError(t, migrator.DropColumn(&StockItemV2{}, "Location"))
+		assert.False(t, migrator.HasColumn(&StockItemV2{}, "location"))
+
+		var names []string
+		require.NoError(t, db.Table("stock_items").Order("id").Pluck("name", &names).Error)
+		assert.Equal(t, []string{"bolt", "nut", "washer"}, names)
+
+		// The unique index on barcode, after location, was rebuilt
+		assert.Error(t, db.Exec("INSERT INTO stock_items (name, barcode) VALUES ('copy', 'W-1')").Error)
+
+		err := migrator.DropColumn(&StockItemV2{}, "Barcode")
+		assert.ErrorIs(t, err, duckdb.ErrUnsupportedAlter)
+		assert.Contains(t, err.Error(), "barcode")
+
+		// A UNIQUE constraint on a later column cannot be rebuilt
+		require.NoError(t, db.Exec("CREATE TABLE stock_labels (id INTEGER, note VARCHAR, code VARCHAR UNIQUE)").Error)
+		err = migrator.DropColumn("stock_labels", "note")
+		assert.ErrorIs(t, err, duckdb.ErrUnsupportedAlter)
+		assert.Contains(t, err.Error(), "column note: a PRIMARY KEY or UNIQUE constraint covers a column after it")
+		assert.True(t, migrator.HasColumn("stock_labels", "note"))
+	})
+}
+
+type MeterReadingV1 struct {
+	ID    uint `gorm:"primaryKey"`
+	Count int16
+	Label string `gorm:"size:100"`
+}
+
+func (MeterReadingV1) TableName() string { return "meter_readings" }
+
+type MeterReadingV2 struct {
+	ID    uint `gorm:"primaryKey"`
+	Count int64
+	Label string `gorm:"type:text"`
+}
+
+func (MeterReadingV2) TableName() string { return "meter_readings" }
+
+func TestMigrator_MigrateColumnType(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+
+	columnType := func(name string) string {
+		t.Helper()
+		columnTypes, err := migrator.ColumnTypes(&MeterReadingV1{})
+		require.NoError(t, err)
+		for _, columnType := range columnTypes {
+			if columnType.Name() == name {
+				return columnType.DatabaseTypeName()
+			}
+		}
+		t.Fatalf("column %s not found", name)
+		return ""
+	}
+
+	require.NoError(t, db.AutoMigrate(&MeterReadingV1{}))
+	require.NoError(t, db.Create(&MeterReadingV1{Count: 30000, Label: "north"}).Error)
+	assert.Equal(t, "SMALLINT", columnType("count"))
+
+	// Widening keeps the stored values; VARCHAR(100) to TEXT is no change
+	require.NoError(t, db.AutoMigrate(&MeterReadingV2{}))
+	assert.Equal(t, "BIGINT", columnType("count"))
+	assert.Equal(t, "VARCHAR", columnType("label"))
+
+	require.NoError(t, db.Create(&MeterReadingV2{Count: 5_000_000_000, Label: "south"}).Error)
+	var counts []int64
+	require.NoError(t, db.Model(&MeterReadingV2{}).Order("id").Pluck("count", &counts).Error)
+	assert.Equal(t, []int64{30000, 5_000_000_000}, counts)
+
+	t.Run("NarrowingLosesData", func(t *testing.T) {
+		err := db.AutoMigrate(&MeterReadingV1{})
+		require.ErrorIs(t, err, duckdb.ErrLossyCoercion)
+		assert.Contains(t, err.Error(), "count")
+		assert.Equal(t, "BIGINT", columnType("count"))
+	})
+
+	t.Run("NarrowingFits", func(t *testing.T) {
+		require.NoError(t, db.Where("count > ?", 32767).Delete(&MeterReadingV2{}).Error)
+		require.NoError(t, db.AutoMigrate(&MeterReadingV1{}))
+		assert.Equal(t, "SMALLINT", columnType("count"))
+
+		var reading MeterReadingV1
+		require.NoError(t, db.First(&reading).Error)
+		assert.Equal(t, int16(30000), reading.Count)
+	})
+}
+
+type ShiftSlot struct {
+	ID      uint   `gorm:"primaryKey"`
+	Day     string `gorm:"uniqueIndex:idx_shift_slot,priority:2"`
+	Station string `gorm:"uniqueIndex:idx_shift_slot,priority:1"`
+	Worker  string `gorm:"index"`
+	Badge   string `gorm:"unique"`
+}
+
+func TestMigrator_GetIndexes(t *testing.T) {
+	db, migrator := setupMigratorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&ShiftSlot{}))
+	require.NoError(t, migrator.CreateIndex(&ShiftSlot{}, "Worker"))
+
+	indexes, err := migrator.GetIndexes(&ShiftSlot{})
+	require.NoError(t, err)
+
+	type indexInfo struct {
+		columns            []string
+		primaryKey, unique bool
+	}
+	byColumns := make(map[string]indexInfo, len(indexes))
+	names := make(map[string]string, len(indexes))
+	for _, index := range indexes {
+		assert.Equal(t, "shift_slots", index.Table())
+		primaryKey, ok := index.PrimaryKey()
+		assert.True(t, ok)
+		unique, ok := index.Unique()
+		assert.True(t, ok)
+
+		key := fmt.Sprint(index.Columns())
+		byColumns[key] = indexInfo{index.Columns(), primaryKey, unique}
+		names[key] = index.Name()
+	}
+	require.Len(t, byColumns, 4)
+
+	assert.Equal(t, indexInfo{[]string{"id"}, true, true}, byColumns["[id]"])
+	assert.Equal(t, indexInfo{[]string{"badge"}, false, true}, byColumns["[badge]"])
+	assert.Equal(t, indexInfo{[]string{"worker"}, false, false}, byColumns["[worker]"])
+
+	// Columns keep the index's order, not the struct's
+	assert.Equal(t, indexInfo{[]string{"station", "day"}, false, true}, byColumns["[station day]"])
+	assert.Equal(t, "idx_shift_slot", names["[station day]"])
+}
+
+type LockedMigrationV1 struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func (LockedMigrationV1) TableName() string { return "locked_migrations" }
+
+type LockedMigrationV2 struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Email string `gorm:"index"`
+}
+
+func (LockedMigrationV2) TableName() string { return "locked_migrations" }
+
+func TestMigrator_MigrationLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "migrate.duckdb")
+	db, err := gorm.Open(duckdb.OpenWithConfig(dbPath, &duckdb.Config{
+		MigrationLock:        true,
+		MigrationLockTimeout: 10 * time.Second,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	t.Run("ConcurrentAutoMigrate", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, model := range []interface{}{&LockedMigrationV1{}, &LockedMigrationV2{}} {
+			wg.Add(1)
+			go func(i int, model interface{}) {
+				defer wg.Done()
+				errs[i] = db.Session(&gorm.Session{NewDB: true}).AutoMigrate(model)
+			}(i, model)
+		}
+		wg.Wait()
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+
+		assert.True(t, db.Migrator().HasColumn("locked_migrations", "email"))
+
+		var held int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM gorm_duckdb_migration_lock").Scan(&held).Error)
+		assert.Equal(t, int64(0), held, "lock should be released after AutoMigrate")
+	})
+
+	t.Run("HeldLockTimesOut", func(t *testing.T) {
+		short, err := gorm.Open(duckdb.New(duckdb.Config{
+			Conn:                 sqlDB,
+			MigrationLock:        true,
+			MigrationLockTimeout: 200 * time.Millisecond,
+		}), &gorm.Config{})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Exec("INSERT INTO gorm_duckdb_migration_lock VALUES (1, 'other', ?)", time.Now().UTC().Add(time.Hour)).Error)
+		err = short.AutoMigrate(&LockedMigrationV2{})
+		require.ErrorIs(t, err, duckdb.ErrMigrationLocked)
+
+		// A lock older than the timeout is taken over as abandoned
+		require.NoError(t, db.Exec("UPDATE gorm_duckdb_migration_lock SET acquired_at = ?", time.Now().UTC().Add(-time.Hour)).Error)
+		require.NoError(t, short.AutoMigrate(&LockedMigrationV2{}))
+	})
+}
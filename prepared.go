@@ -0,0 +1,97 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Prepare registers query as a DuckDB named prepared statement
+// (PREPARE name AS query) so its plan is reused by every Execute. Parameters
+// are written as ? or $1, $2, .... Named statements belong to the connection
+// that prepared them, so db must be pinned: call Prepare and Execute inside
+// db.Connection or a transaction.
+func Prepare(db *gorm.DB, name, query string) error {
+	if !isPinnedConnPool(db.Statement.ConnPool) {
+		return fmt.Errorf("failed to prepare statement %s: named prepared statements require a pinned connection (use db.Connection or a transaction)", name)
+	}
+
+	stmt := "PREPARE " + db.Statement.Quote(name) + " AS " + query
+	if _, err := db.Statement.ConnPool.ExecContext(statementContext(db), stmt); err != nil {
+		return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+	}
+	return nil
+}
+
+// Execute runs the named prepared statement created by Prepare with args.
+// DuckDB does not accept bind parameters in EXECUTE, so args are inlined as
+// DuckDB literals denoting the same values go-duckdb would bind: times keep
+// their exact instant, []byte becomes a BLOB and slices become lists. An
+// argument with no literal form, such as a map, fails before anything runs.
+// The caller must close the returned rows.
+func Execute(db *gorm.DB, name string, args ...interface{}) (*sql.Rows, error) {
+	if !isPinnedConnPool(db.Statement.ConnPool) {
+		return nil, fmt.Errorf("failed to execute statement %s: named prepared statements require a pinned connection (use db.Connection or a transaction)", name)
+	}
+
+	stmt := "EXECUTE " + db.Statement.Quote(name)
+	if len(args) > 0 {
+		literals := make([]string, len(args))
+		for i, arg := range args {
+			literal, err := duckdbLiteral(arg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute statement %s: argument %d: %w", name, i+1, err)
+			}
+			literals[i] = literal
+		}
+		stmt += "(" + strings.Join(literals, ", ") + ")"
+	}
+
+	rows, err := db.Statement.ConnPool.QueryContext(statementContext(db), stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute statement %s: %w", name, err)
+	}
+	return rows, nil
+}
+
+// isPinnedConnPool reports whether pool always runs on the same connection,
+// i.e. it is not a *sql.DB handing out pooled connections
+func isPinnedConnPool(pool gorm.ConnPool) bool {
+	if prepared, ok := pool.(*gorm.PreparedStmtDB); ok {
+		pool = prepared.ConnPool
+	}
+	_, pooled := pool.(*sql.DB)
+	return !pooled
+}
+
+// statementContext returns the context of db's statement, or Background
+func statementContext(db *gorm.DB) context.Context {
+	if db.Statement.Context != nil {
+		return db.Statement.Context
+	}
+	return context.Background()
+}
+
+// ClearStatementCache drops every statement GORM cached for db in PrepareStmt
+// mode, and every statement the driver cached for Config.StatementCacheSize,
+// so the next use of each query prepares it again. DuckDB rebinds prepared
+// statements after most catalog changes on its own; call this after runtime
+// migrations that must not reuse a plan prepared against the old schema. The
+// migrator calls it after AddColumn, AlterColumn and DropColumn. Driver
+// statements still in use are closed once released. It is a no-op when
+// neither cache is on.
+func ClearStatementCache(db *gorm.DB) {
+	switch pool := db.ConnPool.(type) {
+	case *gorm.PreparedStmtDB:
+		pool.Close()
+	case *gorm.PreparedStmtTX:
+		pool.PreparedStmtDB.Close()
+	}
+
+	if config := migratorConfig(db); config != nil && config.stmtGeneration != nil {
+		config.stmtGeneration.Add(1)
+	}
+}
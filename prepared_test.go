@@ -0,0 +1,299 @@
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func collectIDs(t *testing.T, db *gorm.DB, name string, args ...interface{}) []int {
+	t.Helper()
+
+	rows, err := duckdb.Execute(db, name, args...)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	return ids
+}
+
+func TestPrepareExecute(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE prepared_events (id INTEGER, kind VARCHAR)").Error)
+	require.NoError(t, db.Exec("INSERT INTO prepared_events SELECT i, 'k' || (i % 3) FROM range(10) t(i)").Error)
+
+	err := db.Connection(func(conn *gorm.DB) error {
+		require.NoError(t, duckdb.Prepare(conn, "events_by_kind",
+			"SELECT id FROM prepared_events WHERE kind = $1 AND id > $2 ORDER BY id"))
+
+		assert.Equal(t, []int{4, 7}, collectIDs(t, conn, "events_by_kind", "k1", 3))
+		assert.Equal(t, []int{5, 8}, collectIDs(t, conn, "events_by_kind", "k2", 2))
+
+		// String arguments are inlined as escaped literals
+		assert.Empty(t, collectIDs(t, conn, "events_by_kind", "k1' OR '1'='1", 0))
+
+		_, err := duckdb.Execute(conn, "missing_statement")
+		assert.Error(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	t.Run("RequiresPinnedConnection", func(t *testing.T) {
+		assert.Error(t, duckdb.Prepare(db, "unpinned", "SELECT 1"))
+		_, err := duckdb.Execute(db, "unpinned")
+		assert.Error(t, err)
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			require.NoError(t, duckdb.Prepare(tx, "first_event", "SELECT min(id) FROM prepared_events"))
+			assert.Equal(t, []int{0}, collectIDs(t, tx, "first_event"))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestExecuteEncodesArguments(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE prepared_samples (id INTEGER, taken_at TIMESTAMP, payload BLOB, tags VARCHAR[])").Error)
+
+	takenAt := time.Date(2024, 3, 9, 14, 30, 15, 123456000, time.UTC)
+	payload := []byte{0x00, 0xff, '\'', 0x7f, 'a'}
+	tags := []string{"hot", "it's"}
+	require.NoError(t, db.Exec("INSERT INTO prepared_samples VALUES (1, ?, ?, ?), (2, ?, ?, ?)",
+		takenAt, payload, duckdb.NewStringArray(tags),
+		takenAt.Add(time.Millisecond), []byte("other"), duckdb.NewStringArray([]string{"cold"})).Error)
+
+	err := db.Connection(func(conn *gorm.DB) error {
+		require.NoError(t, duckdb.Prepare(conn, "by_time", "SELECT id FROM prepared_samples WHERE taken_at = $1"))
+		require.NoError(t, duckdb.Prepare(conn, "by_payload", "SELECT id FROM prepared_samples WHERE payload = $1"))
+		require.NoError(t, duckdb.Prepare(conn, "by_tags", "SELECT id FROM prepared_samples WHERE tags = $1"))
+
+		// Sub-millisecond precision and the instant survive, whatever the zone
+		assert.Equal(t, []int{1}, collectIDs(t, conn, "by_time", takenAt))
+		assert.Equal(t, []int{1}, collectIDs(t, conn, "by_time", takenAt.In(time.FixedZone("UTC+5", 5*3600))))
+		assert.Empty(t, collectIDs(t, conn, "by_time", takenAt.Add(time.Microsecond)))
+
+		assert.Equal(t, []int{1}, collectIDs(t, conn, "by_payload", payload))
+		assert.Equal(t, []int{2}, collectIDs(t, conn, "by_payload", []byte("other")))
+
+		assert.Equal(t, []int{1}, collectIDs(t, conn, "by_tags", tags))
+		assert.Equal(t, []int{2}, collectIDs(t, conn, "by_tags", []string{"cold"}))
+
+		_, err := duckdb.Execute(conn, "by_tags", map[string]string{"a": "b"})
+		assert.Error(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+type CachedItem struct {
+	ID   uint `gorm:"primaryKey"`
+	Code int32
+}
+
+type CachedItemV2 struct {
+	ID   uint `gorm:"primaryKey"`
+	Code string
+}
+
+func (CachedItemV2) TableName() string { return "cached_items" }
+
+func TestClearStatementCache(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		PrepareStmt: true,
+		Logger:      logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&CachedItem{}))
+	require.NoError(t, db.Create(&CachedItem{Code: 10}).Error)
+
+	preparedDB, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	require.True(t, ok)
+
+	var found CachedItem
+	require.NoError(t, db.Where("code = ?", 10).First(&found).Error)
+	require.NotEmpty(t, preparedDB.Stmts.Keys())
+
+	t.Run("AlterColumnInvalidates", func(t *testing.T) {
+		require.NoError(t, db.Migrator().AlterColumn(&CachedItemV2{}, "Code"))
+		assert.Empty(t, preparedDB.Stmts.Keys())
+
+		var altered CachedItemV2
+		require.NoError(t, db.Where("code = ?", "10").First(&altered).Error)
+		assert.Equal(t, "10", altered.Code)
+	})
+
+	t.Run("Explicit", func(t *testing.T) {
+		var altered CachedItemV2
+		require.NoError(t, db.First(&altered).Error)
+		require.NotEmpty(t, preparedDB.Stmts.Keys())
+
+		duckdb.ClearStatementCache(db)
+		assert.Empty(t, preparedDB.Stmts.Keys())
+		require.NoError(t, db.First(&altered).Error)
+	})
+
+	t.Run("WithoutPrepareStmt", func(t *testing.T) {
+		plain := setupQueryHelpersTestDB(t)
+		assert.NotPanics(t, func() { duckdb.ClearStatementCache(plain) })
+	})
+}
+
+func TestStatementCacheSize(t *testing.T) {
+	db, err := gorm.Open(duckdb.New(duckdb.Config{StatementCacheSize: 2}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&CachedItem{}))
+	require.NoError(t, db.Create(&[]CachedItem{{Code: 1}, {Code: 2}, {Code: 3}}).Error)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	const hot = "SELECT count(*) FROM cached_items WHERE code >= ?"
+	countFrom := func(code int) int {
+		t.Helper()
+		stmt, err := sqlDB.Prepare(hot)
+		require.NoError(t, err)
+		defer stmt.Close()
+
+		var count int
+		require.NoError(t, stmt.QueryRow(code).Scan(&count))
+		return count
+	}
+
+	t.Run("Reuse", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, 3, countFrom(1))
+			assert.Equal(t, 1, countFrom(3))
+		}
+	})
+
+	t.Run("InUse", func(t *testing.T) {
+		first, err := sqlDB.Prepare("SELECT code FROM cached_items WHERE code >= ? ORDER BY code")
+		require.NoError(t, err)
+		defer first.Close()
+		second, err := sqlDB.Prepare("SELECT code FROM cached_items WHERE code >= ? ORDER BY code")
+		require.NoError(t, err)
+		defer second.Close()
+
+		var a, b int
+		require.NoError(t, first.QueryRow(2).Scan(&a))
+		require.NoError(t, second.QueryRow(3).Scan(&b))
+		assert.Equal(t, 2, a)
+		assert.Equal(t, 3, b)
+	})
+
+	t.Run("Eviction", func(t *testing.T) {
+		for _, tc := range []struct {
+			query string
+			want  int
+		}{
+			{"SELECT count(*) FROM cached_items WHERE code > ?", 1},
+			{"SELECT count(*) FROM cached_items WHERE code < ?", 1},
+			{"SELECT count(*) FROM cached_items WHERE code <> ?", 2},
+		} {
+			stmt, err := sqlDB.Prepare(tc.query)
+			require.NoError(t, err)
+			var count int
+			require.NoError(t, stmt.QueryRow(2).Scan(&count))
+			require.NoError(t, stmt.Close())
+			assert.Equal(t, tc.want, count, tc.query)
+		}
+		assert.Equal(t, 3, countFrom(1))
+	})
+
+	t.Run("AlterColumn", func(t *testing.T) {
+		const query = "SELECT code FROM cached_items ORDER BY id LIMIT 1"
+		typeOf := func() string {
+			t.Helper()
+			stmt, err := sqlDB.Prepare(query)
+			require.NoError(t, err)
+			defer stmt.Close()
+
+			rows, err := stmt.Query()
+			require.NoError(t, err)
+			defer rows.Close()
+			types, err := rows.ColumnTypes()
+			require.NoError(t, err)
+			require.Len(t, types, 1)
+			return types[0].DatabaseTypeName()
+		}
+
+		assert.Equal(t, "INTEGER", typeOf())
+		require.NoError(t, db.Migrator().AlterColumn(&CachedItemV2{}, "Code"))
+		assert.Equal(t, "VARCHAR", typeOf())
+
+		var code string
+		require.NoError(t, db.Raw(query).Scan(&code).Error)
+		assert.Equal(t, "1", code)
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		_, err := gorm.Open(duckdb.New(duckdb.Config{StatementCacheSize: -1}), &gorm.Config{})
+		assert.Error(t, err)
+	})
+}
+
+// BenchmarkStatementCache prepares and runs one hot query per iteration with
+// and without Config.StatementCacheSize
+func BenchmarkStatementCache(b *testing.B) {
+	const hot = "SELECT count(*) FROM cached_items WHERE code >= ? AND code < ? + 100"
+
+	for _, bench := range []struct {
+		name string
+		size int
+	}{
+		{"Uncached", 0},
+		{"Cached", 16},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			db, err := gorm.Open(duckdb.New(duckdb.Config{StatementCacheSize: bench.size}), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := db.AutoMigrate(&CachedItem{}); err != nil {
+				b.Fatal(err)
+			}
+			sqlDB, err := db.DB()
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer sqlDB.Close()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				stmt, err := sqlDB.Prepare(hot)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var count int
+				if err := stmt.QueryRow(i, i).Scan(&count); err != nil {
+					b.Fatal(err)
+				}
+				if err := stmt.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package duckdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UnionByName combines two queries with DuckDB's UNION [ALL] BY NAME, which
+// aligns columns by name rather than by position. db is the left-hand query and
+// other the right-hand one; both are rendered as DryRun subqueries, so they can
+// be built with the usual Model/Table/Select/Where chain. Columns missing on one
+// side are filled with NULL. The returned *gorm.DB is a Raw query ready for
+// Scan, Rows or use as a subquery.
+func UnionByName(db *gorm.DB, other *gorm.DB, all bool) *gorm.DB {
+	operator := "UNION BY NAME"
+	if all {
+		operator = "UNION ALL BY NAME"
+	}
+	return db.Session(&gorm.Session{NewDB: true}).Raw("(?) "+operator+" (?)", db, other)
+}
+
+// Pivot runs a DuckDB PIVOT over the rows selected by db (Table/Model plus any
+// Where conditions) and lets DuckDB discover the pivot columns from the data,
+// so on needs no IN list. using is the aggregate (e.g. "sum(amount)") and group
+// the GROUP BY list; either may be empty to use DuckDB's defaults. Because the
+// result columns depend on the data, rows are returned as maps keyed by column.
+//
+// DuckDB does not allow bind parameters in the source of a dynamic PIVOT, so
+// the source query's values are inlined as exact DuckDB literals; a value
+// with no literal form, such as a map, fails the call.
+func Pivot(db *gorm.DB, on, using, group string) ([]map[string]interface{}, error) {
+	dryRun := db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).Find(&[]map[string]interface{}{})
+	if dryRun.Error != nil {
+		return nil, fmt.Errorf("failed to build pivot source: %w", dryRun.Error)
+	}
+	source, err := inlineVars(dryRun.Statement.SQL.String(), dryRun.Statement.Vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pivot source: %w", err)
+	}
+
+	query := "PIVOT (" + source + ") ON " + on
+	if using != "" {
+		query += " USING " + using
+	}
+	if group != "" {
+		query += " GROUP BY " + group
+	}
+
+	var results []map[string]interface{}
+	if err := db.Session(&gorm.Session{NewDB: true}).Raw(query).Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to execute pivot on %s: %w", on, err)
+	}
+	return results, nil
+}
+
+// nonIdentifierChars matches characters that cannot appear in an unquoted alias
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// FromFile points db at a file (or glob / URL) that DuckDB reads through a
+// replacement scan, e.g. FROM 'events.parquet'. Passing such a path to Table
+// would quote it as an identifier; FromFile writes it as a string literal with
+// an alias derived from the file name, so Where/Select/Find work as usual:
+//
+//	var events []Event
+//	duckdb.FromFile(db, "data/events.parquet").Where("kind = ?", "click").Find(&events)
+func FromFile(db *gorm.DB, path string) *gorm.DB {
+	base := filepath.Base(path)
+	if idx := strings.Index(base, "."); idx > 0 {
+		base = base[:idx]
+	}
+	alias := strings.Trim(nonIdentifierChars.ReplaceAllString(base, "_"), "_")
+	if alias == "" || (alias[0] >= '0' && alias[0] <= '9') {
+		alias = "file_" + alias
+	}
+
+	return db.Table(sqlStringLiteral(path) + " AS " + alias)
+}
+
+// PlanContains runs EXPLAIN on query and reports whether the physical plan
+// text contains needle, e.g. "Filters:" for a filter pushed into the scan or
+// "Projections:" for projection pushdown. It is meant for asserting in tests
+// that an optimization still applies. Markers are matched against DuckDB's
+// rendered plan boxes, so keep them short enough not to wrap across lines.
+func PlanContains(db *gorm.DB, query string, vars []interface{}, needle string) (bool, error) {
+	rows, err := db.Session(&gorm.Session{NewDB: true}).Raw("EXPLAIN "+query, vars...).Rows()
+	if err != nil {
+		return false, fmt.Errorf("failed to explain query: %w", err)
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return false, fmt.Errorf("failed to read query plan: %w", err)
+		}
+		plan.WriteString(value)
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to read query plan: %w", err)
+	}
+
+	return strings.Contains(plan.String(), needle), nil
+}
+
+// Bit string helpers
+//
+// The helpers below build DuckDB bit-string expressions as clause.Expr values
+// for BIT columns (see BitStringType), e.g. a permission check:
+//
+//	mask, _ := duckdb.NewBitStringFromString("0100", 4)
+//	db.Where("? = ?", duckdb.BitAnd("perms", mask), mask).Find(&users)
+//
+// Masks may be a BitStringType, a binary string such as "0100", or any other
+// expression; strings are cast to BIT. Bitwise operands must have the same
+// length. go-duckdb can neither bind nor scan BIT values directly, so compare
+// results against a BitStringType (or cast them to VARCHAR), and select
+// BIT-valued expressions with a VARCHAR cast.
+
+// BitCount returns bit_count(column), the number of set bits.
+func BitCount(column string) clause.Expr {
+	return clause.Expr{SQL: "bit_count(?)", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// BitAnd returns (column & mask).
+func BitAnd(column string, mask interface{}) clause.Expr {
+	return clause.Expr{SQL: "(? & ?)", Vars: []interface{}{clause.Column{Name: column}, bitOperand(mask)}}
+}
+
+// BitOr returns (column | mask).
+func BitOr(column string, mask interface{}) clause.Expr {
+	return clause.Expr{SQL: "(? | ?)", Vars: []interface{}{clause.Column{Name: column}, bitOperand(mask)}}
+}
+
+// BitXor returns xor(column, mask).
+func BitXor(column string, mask interface{}) clause.Expr {
+	return clause.Expr{SQL: "xor(?, ?)", Vars: []interface{}{clause.Column{Name: column}, bitOperand(mask)}}
+}
+
+// GetBit returns get_bit(column, index), 0 or 1. Index 0 is the leftmost bit,
+// matching BitStringType.Get.
+func GetBit(column string, index int) clause.Expr {
+	return clause.Expr{SQL: "get_bit(?, CAST(? AS INTEGER))", Vars: []interface{}{clause.Column{Name: column}, index}}
+}
+
+// SetBit returns set_bit(column, index, value), the column with the bit at
+// index (leftmost is 0) set or cleared. Use it with Update to flip a flag in
+// place.
+func SetBit(column string, index int, value bool) clause.Expr {
+	bit := 0
+	if value {
+		bit = 1
+	}
+	return clause.Expr{SQL: "set_bit(?, CAST(? AS INTEGER), CAST(? AS INTEGER))", Vars: []interface{}{clause.Column{Name: column}, index, bit}}
+}
+
+// bitOperand casts binary strings to BIT; other operands are bound as-is
+func bitOperand(mask interface{}) interface{} {
+	if bits, ok := mask.(string); ok {
+		return bitLiteralExpr(bits)
+	}
+	return mask
+}
+
+// ApproxCount returns DuckDB's estimated row count for model's table, read from
+// the table statistics in duckdb_tables() instead of scanning with COUNT(*).
+// It is meant for dashboards where an estimate is good enough: deleted rows may
+// still be counted until the table is checkpointed, and conditions set on db
+// are ignored. The table is looked up in the current database unless model's
+// table name is qualified as database.schema.table. Views and other relations
+// without statistics fall back to an exact count.
+func ApproxCount(db *gorm.DB, model interface{}) (int64, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return 0, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	// An unqualified table resolves against the current database and schema,
+	// which also keeps same-named tables of attached databases out
+	parts := strings.Split(stmt.Table, ".")
+	conds := []string{"table_name = ?", "schema_name = current_schema()", "database_name = current_database()"}
+	vars := []interface{}{parts[len(parts)-1]}
+	if len(parts) >= 2 {
+		conds[1] = "schema_name = ?"
+		vars = append(vars, parts[len(parts)-2])
+	}
+	if len(parts) >= 3 {
+		conds[2] = "database_name = ?"
+		vars = append(vars, strings.Join(parts[:len(parts)-2], "."))
+	}
+	query := "SELECT estimated_size FROM duckdb_tables() WHERE " + strings.Join(conds, " AND ")
+
+	var estimates []int64
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if err := tx.Raw(query, vars...).Scan(&estimates).Error; err != nil {
+		return 0, fmt.Errorf("failed to read table statistics for %s: %w", stmt.Table, err)
+	}
+	if len(estimates) > 0 {
+		return estimates[0], nil
+	}
+
+	var count int64
+	if err := tx.Table(stmt.Table).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", stmt.Table, err)
+	}
+	return count, nil
+}
+
+// CurrentDatabase returns the catalog unqualified names resolve against,
+// e.g. "memory" for an in-memory database or the file name without extension.
+// USE is per connection, so call it on the same connection (db.Connection or
+// a transaction) that ran the USE statement.
+func CurrentDatabase(db *gorm.DB) (string, error) {
+	return currentSetting(db, "current_database()")
+}
+
+// CurrentSchema returns the schema unqualified names resolve against, "main"
+// unless changed with USE or SET schema. See CurrentDatabase for USE scoping.
+func CurrentSchema(db *gorm.DB) (string, error) {
+	return currentSetting(db, "current_schema()")
+}
+
+// Use switches the default catalog (or "catalog.schema") of the connection
+// behind db with USE, so unqualified table names resolve there. USE is per
+// connection and the pool hands out any connection, so run Use and the
+// statements that rely on it in one transaction:
+//
+//	db.Transaction(func(tx *gorm.DB) error {
+//		if err := duckdb.Use(tx, "reporting"); err != nil {
+//			return err
+//		}
+//		return tx.Create(&report).Error
+//	})
+//
+// db.Connection pins a connection too, but GORM's default transaction around
+// Create/Update/Delete hands the statement back to the pool when it commits,
+// so pair it with SkipDefaultTransaction. To make every pooled connection
+// default to a catalog, issue USE from Config.OnConnect instead.
+func Use(db *gorm.DB, catalog string) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if err := tx.Exec("USE " + tx.Statement.Quote(catalog)).Error; err != nil {
+		return fmt.Errorf("failed to use %s: %w", catalog, err)
+	}
+	return nil
+}
+
+// GetSetting returns the current value of the DuckDB setting name as text,
+// e.g. GetSetting(db, "checkpoint_threshold") returns "16.0 MiB" by default.
+func GetSetting(db *gorm.DB, name string) (string, error) {
+	return currentSetting(db, "current_setting("+sqlStringLiteral(name)+")::VARCHAR")
+}
+
+// Checkpoint writes the WAL of db's database into the database file with
+// CHECKPOINT, or FORCE CHECKPOINT when force is set, which aborts running
+// transactions instead of waiting for them. DuckDB also checkpoints on its
+// own once the WAL reaches Config.CheckpointThreshold, and when the database
+// is closed.
+func Checkpoint(db *gorm.DB, force bool) error {
+	statement := "CHECKPOINT"
+	if force {
+		statement = "FORCE CHECKPOINT"
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Exec(statement).Error; err != nil {
+		return fmt.Errorf("failed to checkpoint: %w", err)
+	}
+	return nil
+}
+
+func currentSetting(db *gorm.DB, function string) (string, error) {
+	var name string
+	if err := db.Session(&gorm.Session{NewDB: true}).Raw("SELECT " + function).Row().Scan(&name); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", function, err)
+	}
+	return name, nil
+}
+
+// Conditional helpers
+//
+// If, Coalesce and NullIf build DuckDB's conditional functions as clause.Expr
+// values. Plain Go values are bound as parameters; use clause.Column (or
+// gorm.Expr) to reference a column or write SQL:
+//
+//	db.Model(&User{}).
+//		Select("? AS age_group, COUNT(*) AS count", duckdb.If(gorm.Expr("age < ?", 30), "Young", "Mature")).
+//		Group("age_group").Scan(&stats)
+
+// If returns if(cond, then, otherwise), a compact CASE WHEN cond THEN then
+// ELSE otherwise END.
+func If(cond clause.Expression, then, otherwise interface{}) clause.Expr {
+	return clause.Expr{SQL: "if(?, ?, ?)", Vars: []interface{}{cond, then, otherwise}}
+}
+
+// Coalesce returns coalesce(values...), the first non-NULL value.
+func Coalesce(values ...interface{}) clause.Expr {
+	if len(values) == 0 {
+		return clause.Expr{SQL: nullValue}
+	}
+	return clause.Expr{SQL: "coalesce(" + strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ") + ")", Vars: values}
+}
+
+// NullIf returns nullif(a, b), which is NULL when a equals b and a otherwise.
+func NullIf(a, b interface{}) clause.Expr {
+	return clause.Expr{SQL: "nullif(?, ?)", Vars: []interface{}{a, b}}
+}
+
+// DeduplicateLatest keeps one row per partitionBy key: the first row in
+// orderBy order (e.g. "updated_at DESC" for the latest version). It wraps
+// db's query for model in DuckDB's QUALIFY row_number() pattern:
+//
+//	var current []Event
+//	duckdb.DeduplicateLatest(db, &Event{}, []string{"device_id"}, "recorded_at DESC").Find(&current)
+//
+// Conditions already on db filter rows before deduplication; Where, Order and
+// Limit chained on the result apply to the deduplicated rows. orderBy is
+// written as SQL, so it must not contain untrusted input.
+func DeduplicateLatest(db *gorm.DB, model interface{}, partitionBy []string, orderBy string) *gorm.DB {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		tx := db.Session(&gorm.Session{NewDB: true})
+		_ = tx.AddError(fmt.Errorf("failed to parse model: %w", err))
+		return tx
+	}
+
+	window := ""
+	vars := []interface{}{db.Model(model)}
+	if len(partitionBy) > 0 {
+		window = "PARTITION BY " + strings.TrimSuffix(strings.Repeat("?, ", len(partitionBy)), ", ")
+		for _, column := range partitionBy {
+			vars = append(vars, clause.Column{Name: column})
+		}
+	}
+	if orderBy != "" {
+		window = strings.TrimSpace(window + " ORDER BY " + orderBy)
+	}
+
+	tx := db.Session(&gorm.Session{NewDB: true})
+	latest := tx.Raw("SELECT * FROM (?) QUALIFY row_number() OVER ("+window+") = 1", vars...)
+	return tx.Table("(?) AS ?", latest, clause.Table{Name: stmt.Table})
+}
+
+// FlattenJSON projects nested JSON fields of column into named columns. paths
+// maps each result column to a JSON path ("$.user.address.city", or a JSON
+// pointer such as "/user/address/city"); every entry becomes
+// json_extract(column, path) AS alias. Expressions are ordered by alias.
+// Combine them with clause.CommaExpression to select them:
+//
+//	fields := duckdb.FlattenJSON("payload", map[string]string{"city": "$.user.address.city", "zip": "$.user.address.zip"})
+//	db.Table("events").Select("id, ?", clause.CommaExpression{Exprs: fields}).Scan(&rows)
+//
+// json_extract returns JSON, which go-duckdb decodes to strings, numbers,
+// booleans, maps and slices; missing paths are NULL.
+func FlattenJSON(column string, paths map[string]string) []clause.Expression {
+	aliases := make([]string, 0, len(paths))
+	for alias := range paths {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	exprs := make([]clause.Expression, len(aliases))
+	for i, alias := range aliases {
+		exprs[i] = clause.Expr{
+			SQL:  "json_extract(?, ?) AS ?",
+			Vars: []interface{}{clause.Column{Name: column}, paths[alias], clause.Column{Name: alias}},
+		}
+	}
+	return exprs
+}
+
+// JSON path helpers
+//
+// JSONExtract and JSONContains query into JSON columns (see JSON and
+// JSONType) with DuckDB's json extension:
+//
+//	db.Where(duckdb.JSONExtract("data", "$.status").Eq("active")).Find(&rows)
+//	db.Where(duckdb.JSONContains("data", map[string]interface{}{"tags": []string{"go"}})).Find(&rows)
+//
+// Values are encoded with encoding/json and compared as JSON, so "active"
+// matches the JSON string and 5 the JSON number. The json extension ships
+// with go-duckdb and autoloads; when it is unavailable, queries fail and
+// ErrorTranslator (gorm.Config.TranslateError) reports ErrJSONExtension.
+
+// JSONPath is the value at a JSON path of a column, json_extract(column,
+// path). It is a clause.Expression for Select and Order.
+type JSONPath struct {
+	column string
+	path   string
+}
+
+// JSONExtract returns the value at path ("$.status", or a JSON pointer such as
+// "/status") of the JSON column.
+func JSONExtract(column, path string) JSONPath {
+	return JSONPath{column: column, path: path}
+}
+
+// Build implements clause.Expression.
+func (p JSONPath) Build(builder clause.Builder) {
+	clause.Expr{SQL: "json_extract(?, ?)", Vars: []interface{}{clause.Column{Name: p.column}, p.path}}.Build(builder)
+}
+
+// Text returns json_extract_string(column, path), the value as VARCHAR
+// without JSON quoting.
+func (p JSONPath) Text() clause.Expr {
+	return clause.Expr{SQL: "json_extract_string(?, ?)", Vars: []interface{}{clause.Column{Name: p.column}, p.path}}
+}
+
+// Eq returns a condition that the value at the path equals value.
+func (p JSONPath) Eq(value interface{}) clause.Expr {
+	return clause.Expr{SQL: "? = ?", Vars: []interface{}{p, jsonOperand{value}}}
+}
+
+// Neq returns a condition that the value at the path differs from value.
+// Rows where the path is missing do not match.
+func (p JSONPath) Neq(value interface{}) clause.Expr {
+	return clause.Expr{SQL: "? <> ?", Vars: []interface{}{p, jsonOperand{value}}}
+}
+
+// JSONContains returns json_contains(column, value), a condition that the
+// JSON column contains value: a matching scalar, or an object or array whose
+// entries are all present.
+func JSONContains(column string, value interface{}) clause.Expr {
+	return clause.Expr{SQL: "json_contains(?, ?)", Vars: []interface{}{clause.Column{Name: column}, jsonOperand{value}}}
+}
+
+// jsonOperand is a Go value encoded as JSON and cast to DuckDB's JSON type
+type jsonOperand struct {
+	value interface{}
+}
+
+func (o jsonOperand) Build(builder clause.Builder) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(o.value); err != nil {
+		_ = builder.AddError(fmt.Errorf("failed to encode %T as JSON: %w", o.value, err))
+		return
+	}
+	clause.Expr{SQL: "CAST(? AS JSON)", Vars: []interface{}{strings.TrimSuffix(encoded.String(), "\n")}}.Build(builder)
+}
+
+// Quantile helpers
+//
+// ApproxQuantile and ReservoirQuantile estimate percentiles without sorting
+// the whole column, for use in Select:
+//
+//	db.Model(&Request{}).
+//		Select("? AS p50, ? AS p95", duckdb.ApproxQuantile("latency", 0.5), duckdb.ApproxQuantile("latency", 0.95)).
+//		Scan(&stats)
+//
+// approx_quantile uses a T-Digest sketch; reservoir_quantile samples a fixed
+// reservoir of rows. DuckDB only accepts constant quantiles, so they are
+// written into the SQL rather than bound. The multi-quantile variants return
+// one list (scan it into a FloatArray for numeric columns).
+
+// ApproxQuantile returns approx_quantile(column, q) for q between 0 and 1.
+func ApproxQuantile(column string, q float64) clause.Expr {
+	return clause.Expr{SQL: "approx_quantile(?, " + quantileLiteral(q) + ")", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ApproxQuantiles returns approx_quantile(column, [qs...]), a list holding
+// one estimate per quantile.
+func ApproxQuantiles(column string, qs ...float64) clause.Expr {
+	return clause.Expr{SQL: "approx_quantile(?, " + quantileList(qs) + ")", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ReservoirQuantile returns reservoir_quantile(column, q) for q between 0
+// and 1.
+func ReservoirQuantile(column string, q float64) clause.Expr {
+	return clause.Expr{SQL: "reservoir_quantile(?, " + quantileLiteral(q) + ")", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// ReservoirQuantiles returns reservoir_quantile(column, [qs...]), a list
+// holding one estimate per quantile.
+func ReservoirQuantiles(column string, qs ...float64) clause.Expr {
+	return clause.Expr{SQL: "reservoir_quantile(?, " + quantileList(qs) + ")", Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// quantileLiteral formats q as a SQL number
+func quantileLiteral(q float64) string {
+	return strconv.FormatFloat(q, 'g', -1, 64)
+}
+
+// quantileList formats qs as a SQL list of numbers
+func quantileList(qs []float64) string {
+	quantiles := make([]string, len(qs))
+	for i, q := range qs {
+		quantiles[i] = quantileLiteral(q)
+	}
+	return "[" + strings.Join(quantiles, ", ") + "]"
+}
+
+// Filtered aggregates
+//
+// CountFilter, SumFilter and AvgFilter restrict an aggregate to the rows
+// matching a condition with DuckDB's FILTER clause, so several conditional
+// aggregates run in one scan without CASE expressions:
+//
+//	db.Model(&Order{}).
+//		Select(duckdb.CountFilter("status = 'paid'").As("paid"), duckdb.SumFilter("amount", "status = 'paid'").As("revenue")).
+//		Scan(&summary)
+//
+// As returns the aliased aggregate as a select string; without an alias the
+// aggregate is a clause.Expression for use as a "?" argument. Columns and
+// conditions are written as SQL, so they must not contain untrusted input.
+
+// FilteredAggregate is an aggregate with a FILTER (WHERE ...) clause.
+type FilteredAggregate struct {
+	function  string
+	column    string
+	condition string
+}
+
+// CountFilter returns count(*) FILTER (WHERE condition).
+func CountFilter(condition string) FilteredAggregate {
+	return FilteredAggregate{function: "count", column: "*", condition: condition}
+}
+
+// SumFilter returns sum(column) FILTER (WHERE condition).
+func SumFilter(column, condition string) FilteredAggregate {
+	return FilteredAggregate{function: "sum", column: column, condition: condition}
+}
+
+// AvgFilter returns avg(column) FILTER (WHERE condition).
+func AvgFilter(column, condition string) FilteredAggregate {
+	return FilteredAggregate{function: "avg", column: column, condition: condition}
+}
+
+// String returns the aggregate as SQL.
+func (a FilteredAggregate) String() string {
+	return a.function + "(" + a.column + ") FILTER (WHERE " + a.condition + ")"
+}
+
+// As returns the aggregate aliased as alias, for Select.
+func (a FilteredAggregate) As(alias string) string {
+	return a.String() + " AS " + sqlIdentifier(alias)
+}
+
+// Build implements clause.Expression.
+func (a FilteredAggregate) Build(builder clause.Builder) {
+	builder.WriteString(a.String())
+}
+
+// String helpers
+//
+// RegexpMatches, RegexpExtract and StringSplit build DuckDB's text functions
+// as clause.Expr values, for parsing semi-structured text columns in SQL:
+//
+//	db.Model(&LogLine{}).
+//		Select("id, ? AS tags", duckdb.StringSplit("tags", ",")).
+//		Where(duckdb.RegexpMatches("message", `^ERROR \d+`)).
+//		Scan(&rows)
+//
+// Patterns use RE2 syntax and are bound as parameters. StringSplit returns a
+// VARCHAR[] list; scan it into a StringArray.
+
+// RegexpMatches returns regexp_matches(column, pattern), a condition that
+// pattern matches anywhere in the column.
+func RegexpMatches(column, pattern string) clause.Expr {
+	return clause.Expr{SQL: "regexp_matches(?, ?)", Vars: []interface{}{clause.Column{Name: column}, pattern}}
+}
+
+// RegexpExtract returns regexp_extract(column, pattern, group), the text
+// captured by group (0 for the whole match) of the first match, or an empty
+// string when nothing matches. DuckDB only accepts a constant group, so it is
+// written into the SQL rather than bound.
+func RegexpExtract(column, pattern string, group int) clause.Expr {
+	return clause.Expr{
+		SQL:  "regexp_extract(?, ?, " + strconv.Itoa(group) + ")",
+		Vars: []interface{}{clause.Column{Name: column}, pattern},
+	}
+}
+
+// StringSplit returns string_split(column, separator), the column split into
+// a list of strings at every occurrence of separator.
+func StringSplit(column, separator string) clause.Expr {
+	return clause.Expr{SQL: "string_split(?, ?)", Vars: []interface{}{clause.Column{Name: column}, separator}}
+}
+
+// StructField returns struct_extract(column, 'field'), a member of a STRUCT
+// column (see Struct and StructType) for use in Where, Select or Order:
+//
+//	db.Where("? = ?", duckdb.StructField("address", "city"), "Amsterdam").Find(&customers)
+//
+// field is the member's DuckDB name; a dotted path such as "location.lat"
+// reaches into nested structs. DuckDB only accepts constant member names, so
+// they are written into the SQL as string literals rather than bound.
+func StructField(column, field string) clause.Expr {
+	sql := "?"
+	for _, member := range strings.Split(field, ".") {
+		sql = "struct_extract(" + sql + ", " + sqlStringLiteral(member) + ")"
+	}
+	return clause.Expr{SQL: sql, Vars: []interface{}{clause.Column{Name: column}}}
+}
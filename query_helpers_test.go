@@ -0,0 +1,747 @@
+package duckdb_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type UnionSensor struct {
+	ID     uint `gorm:"primaryKey"`
+	Name   string
+	Region string
+}
+
+type UnionDevice struct {
+	ID     uint `gorm:"primaryKey"`
+	Region string
+	Name   string
+	Vendor string
+}
+
+func setupQueryHelpersTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestUnionByName(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&UnionSensor{}, &UnionDevice{}))
+
+	require.NoError(t, db.Create(&UnionSensor{Name: "thermo", Region: "eu"}).Error)
+	require.NoError(t, db.Create(&UnionDevice{Region: "us", Name: "router", Vendor: "acme"}).Error)
+	require.NoError(t, db.Create(&UnionDevice{Region: "us", Name: "router", Vendor: "acme"}).Error)
+
+	type result struct {
+		Name   string
+		Region string
+		Vendor *string
+	}
+
+	t.Run("DifferentColumnOrder", func(t *testing.T) {
+		left := db.Model(&UnionSensor{}).Select("name, region")
+		right := db.Model(&UnionDevice{}).Select("region, name, vendor")
+
+		var rows []result
+		union := duckdb.UnionByName(left, right, true)
+		err := db.Table("(?) AS u", union).Order("name").Scan(&rows).Error
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Equal(t, "router", rows[0].Name)
+		assert.Equal(t, "us", rows[0].Region)
+		require.NotNil(t, rows[0].Vendor)
+		assert.Equal(t, "acme", *rows[0].Vendor)
+
+		assert.Equal(t, "thermo", rows[2].Name)
+		assert.Equal(t, "eu", rows[2].Region)
+		assert.Nil(t, rows[2].Vendor)
+	})
+
+	t.Run("Distinct", func(t *testing.T) {
+		left := db.Model(&UnionSensor{}).Select("region, name").Where("name = ?", "thermo")
+		right := db.Model(&UnionDevice{}).Select("name, region")
+
+		var rows []result
+		err := duckdb.UnionByName(left, right, false).Scan(&rows).Error
+		require.NoError(t, err)
+		assert.Len(t, rows, 2)
+	})
+}
+
+type PivotSale struct {
+	ID      uint `gorm:"primaryKey"`
+	Region  string
+	Product string
+	Amount  int
+}
+
+func TestPivot_DiscoversValues(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&PivotSale{}))
+
+	sales := []PivotSale{
+		{Region: "eu", Product: "apples", Amount: 3},
+		{Region: "eu", Product: "pears", Amount: 4},
+		{Region: "eu", Product: "apples", Amount: 5},
+		{Region: "us", Product: "apples", Amount: 7},
+	}
+	for i := range sales {
+		require.NoError(t, db.Create(&sales[i]).Error)
+	}
+
+	byRegion := func(rows []map[string]interface{}) map[string]map[string]interface{} {
+		indexed := make(map[string]map[string]interface{}, len(rows))
+		for _, row := range rows {
+			indexed[fmt.Sprint(row["region"])] = row
+		}
+		return indexed
+	}
+
+	rows, err := duckdb.Pivot(db.Model(&PivotSale{}).Select("region, product, amount"), "product", "sum(amount)", "region")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	indexed := byRegion(rows)
+	assert.Equal(t, "8", fmt.Sprint(indexed["eu"]["apples"]))
+	assert.Equal(t, "4", fmt.Sprint(indexed["eu"]["pears"]))
+	assert.Equal(t, "7", fmt.Sprint(indexed["us"]["apples"]))
+	assert.Nil(t, indexed["us"]["pears"])
+	assert.NotContains(t, indexed["eu"], "plums")
+
+	// A new category shows up as a new column without changing the call
+	require.NoError(t, db.Create(&PivotSale{Region: "us", Product: "plums", Amount: 2}).Error)
+
+	rows, err = duckdb.Pivot(db.Model(&PivotSale{}).Select("region, product, amount").Where("region = ?", "us"), "product", "sum(amount)", "region")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "2", fmt.Sprint(rows[0]["plums"]))
+	assert.Equal(t, "7", fmt.Sprint(rows[0]["apples"]))
+	assert.NotContains(t, rows[0], "pears")
+}
+
+type PivotReading struct {
+	ID      uint `gorm:"primaryKey"`
+	Sensor  string
+	Kind    string
+	Amount  int
+	TakenAt time.Time
+}
+
+func TestPivot_TimestampFilter(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&PivotReading{}))
+
+	start := time.Date(2024, 5, 1, 8, 0, 0, 100000, time.UTC)
+	readings := []PivotReading{
+		{Sensor: "a", Kind: "temp", Amount: 1, TakenAt: start},
+		{Sensor: "a", Kind: "temp", Amount: 10, TakenAt: start.Add(500 * time.Microsecond)},
+		{Sensor: "a", Kind: "hum", Amount: 20, TakenAt: start.Add(700 * time.Microsecond)},
+	}
+	for i := range readings {
+		require.NoError(t, db.Create(&readings[i]).Error)
+	}
+
+	// The cut-off lies inside the first millisecond and is given in another
+	// zone; rendering it with millisecond precision would keep every row
+	cutoff := start.Add(250 * time.Microsecond).In(time.FixedZone("UTC-3", -3*3600))
+	source := db.Model(&PivotReading{}).Select("sensor, kind, amount").Where("taken_at > ?", cutoff)
+
+	rows, err := duckdb.Pivot(source, "kind", "sum(amount)", "sensor")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "10", fmt.Sprint(rows[0]["temp"]))
+	assert.Equal(t, "20", fmt.Sprint(rows[0]["hum"]))
+}
+
+func TestFromFile_Parquet(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+
+	path := filepath.Join(t.TempDir(), "page-views.parquet")
+	require.NoError(t, db.Exec(fmt.Sprintf(
+		"COPY (SELECT * FROM (VALUES (1, 'home', 10), (2, 'docs', 25), (3, 'blog', 5)) AS t(id, page, views)) TO '%s' (FORMAT PARQUET)",
+		path,
+	)).Error)
+
+	type pageView struct {
+		ID    int
+		Page  string
+		Views int
+	}
+
+	var all []pageView
+	require.NoError(t, duckdb.FromFile(db, path).Order("id").Find(&all).Error)
+	require.Len(t, all, 3)
+	assert.Equal(t, pageView{ID: 2, Page: "docs", Views: 25}, all[1])
+
+	var popular []pageView
+	err := duckdb.FromFile(db, path).Select("page, views").Where("views > ?", 8).Order("views DESC").Find(&popular).Error
+	require.NoError(t, err)
+	require.Len(t, popular, 2)
+	assert.Equal(t, "docs", popular[0].Page)
+	assert.Equal(t, "home", popular[1].Page)
+
+	var count int64
+	require.NoError(t, duckdb.FromFile(db, path).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestPlanContains(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE plan_events (id INTEGER, kind VARCHAR, value DOUBLE)").Error)
+	require.NoError(t, db.Exec("INSERT INTO plan_events SELECT i, 'k' || (i % 3), i FROM range(1000) t(i)").Error)
+
+	query := "SELECT id FROM plan_events WHERE kind = ?"
+
+	pushed, err := duckdb.PlanContains(db, query, []interface{}{"k1"}, "Filters:")
+	require.NoError(t, err)
+	assert.True(t, pushed, "filter should be pushed down into the scan")
+
+	projected, err := duckdb.PlanContains(db, query, []interface{}{"k1"}, "Projections: id")
+	require.NoError(t, err)
+	assert.True(t, projected, "only the selected column should be read")
+
+	unfiltered, err := duckdb.PlanContains(db, "SELECT id FROM plan_events", nil, "Filters:")
+	require.NoError(t, err)
+	assert.False(t, unfiltered)
+
+	_, err = duckdb.PlanContains(db, "SELECT id FROM missing_table", nil, "Filters:")
+	assert.Error(t, err)
+}
+
+type BitAccount struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Perms duckdb.BitStringType
+}
+
+func TestBitStringHelpers(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&BitAccount{}))
+
+	for name, bits := range map[string]string{"reader": "0001", "writer": "0011", "admin": "0111", "auditor": "1001"} {
+		perms, err := duckdb.NewBitStringFromString(bits, 4)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(&BitAccount{Name: name, Perms: perms}).Error)
+	}
+
+	write, err := duckdb.NewBitStringFromString("0010", 4)
+	require.NoError(t, err)
+
+	t.Run("MaskFilter", func(t *testing.T) {
+		var names []string
+		err := db.Model(&BitAccount{}).Where("? = ?", duckdb.BitAnd("perms", write), write).Order("name").Pluck("name", &names).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "writer"}, names)
+
+		// Binary strings are accepted as masks; compare the result as text
+		err = db.Model(&BitAccount{}).Where("CAST(? AS VARCHAR) = ?", duckdb.BitAnd("perms", "1000"), "1000").Pluck("name", &names).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"auditor"}, names)
+	})
+
+	t.Run("BitCount", func(t *testing.T) {
+		var names []string
+		err := db.Model(&BitAccount{}).Where("? >= ?", duckdb.BitCount("perms"), 2).Order("name").Pluck("name", &names).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "auditor", "writer"}, names)
+	})
+
+	t.Run("OrXor", func(t *testing.T) {
+		var orBits, xorBits string
+		row := db.Model(&BitAccount{}).Where("name = ?", "reader").
+			Select("CAST(? AS VARCHAR), CAST(? AS VARCHAR)", duckdb.BitOr("perms", write), duckdb.BitXor("perms", "0101")).Row()
+		require.NoError(t, row.Scan(&orBits, &xorBits))
+		assert.Equal(t, "0011", orBits)
+		assert.Equal(t, "0100", xorBits)
+	})
+
+	t.Run("GetSetBit", func(t *testing.T) {
+		require.NoError(t, db.Model(&BitAccount{}).Where("name = ?", "reader").Update("perms", duckdb.SetBit("perms", 2, true)).Error)
+
+		var names []string
+		err := db.Model(&BitAccount{}).Where("? = 1", duckdb.GetBit("perms", 2)).Order("name").Pluck("name", &names).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "reader", "writer"}, names)
+	})
+}
+
+type ApproxMetric struct {
+	ID    uint `gorm:"primaryKey"`
+	Value float64
+}
+
+type ApproxMetricView struct {
+	ID    uint
+	Value float64
+}
+
+func (ApproxMetricView) TableName() string { return "approx_metric_views" }
+
+type ArchivedApproxMetric struct {
+	ID    uint
+	Value float64
+}
+
+func (ArchivedApproxMetric) TableName() string { return "approx_archive.main.approx_metrics" }
+
+func TestApproxCount(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&ApproxMetric{}))
+	require.NoError(t, db.Exec("INSERT INTO approx_metrics (id, value) SELECT i + 1, random() FROM range(50000) t(i)").Error)
+
+	var exact int64
+	require.NoError(t, db.Model(&ApproxMetric{}).Count(&exact).Error)
+
+	approx, err := duckdb.ApproxCount(db, &ApproxMetric{})
+	require.NoError(t, err)
+	assert.InDelta(t, exact, approx, float64(exact)*0.05)
+
+	t.Run("ViewFallsBackToExact", func(t *testing.T) {
+		require.NoError(t, db.Exec("CREATE VIEW approx_metric_views AS SELECT * FROM approx_metrics WHERE value < 0.5").Error)
+
+		var viewCount int64
+		require.NoError(t, db.Table("approx_metric_views").Count(&viewCount).Error)
+
+		approx, err := duckdb.ApproxCount(db, &ApproxMetricView{})
+		require.NoError(t, err)
+		assert.Equal(t, viewCount, approx)
+	})
+
+	t.Run("AttachedDatabase", func(t *testing.T) {
+		manager := duckdb.NewExtensionManager(db, nil)
+		require.NoError(t, manager.Attach(filepath.Join(t.TempDir(), "approx_archive.duckdb"), "approx_archive", false))
+		defer manager.Detach("approx_archive")
+		require.NoError(t, db.Exec("CREATE TABLE approx_archive.main.approx_metrics (id INTEGER, value DOUBLE)").Error)
+		require.NoError(t, db.Exec("INSERT INTO approx_archive.main.approx_metrics SELECT i, random() FROM range(10) t(i)").Error)
+
+		approx, err := duckdb.ApproxCount(db, &ApproxMetric{})
+		require.NoError(t, err)
+		assert.InDelta(t, exact, approx, float64(exact)*0.05)
+
+		archived, err := duckdb.ApproxCount(db, &ArchivedApproxMetric{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), archived)
+	})
+
+	t.Run("InvalidModel", func(t *testing.T) {
+		_, err := duckdb.ApproxCount(db, 42)
+		assert.Error(t, err)
+	})
+}
+
+type BucketUser struct {
+	ID       uint `gorm:"primaryKey"`
+	Name     string
+	Nickname *string
+	Age      uint8
+}
+
+func TestConditionalHelpers(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&BucketUser{}))
+
+	nick := "bobby"
+	require.NoError(t, db.Create(&[]BucketUser{
+		{Name: "Alice Johnson", Age: 25},
+		{Name: "Bob Smith", Nickname: &nick, Age: 30},
+		{Name: "Charlie Brown", Age: 35},
+	}).Error)
+
+	t.Run("If", func(t *testing.T) {
+		type ageStat struct {
+			AgeGroup string
+			Count    int64
+		}
+		var stats []ageStat
+		err := db.Model(&BucketUser{}).
+			Select("? AS age_group, COUNT(*) AS count", duckdb.If(gorm.Expr("age < ?", 30), "Young", "Mature")).
+			Group("age_group").Order("age_group").Scan(&stats).Error
+		require.NoError(t, err)
+		assert.Equal(t, []ageStat{{"Mature", 2}, {"Young", 1}}, stats)
+	})
+
+	t.Run("Coalesce", func(t *testing.T) {
+		var labels []string
+		err := db.Model(&BucketUser{}).
+			Select("?", duckdb.Coalesce(clause.Column{Name: "nickname"}, clause.Column{Name: "name"})).
+			Order("id").Scan(&labels).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alice Johnson", "bobby", "Charlie Brown"}, labels)
+	})
+
+	t.Run("NullIf", func(t *testing.T) {
+		var rows []struct{ Age *int64 }
+		err := db.Model(&BucketUser{}).Select("? AS age", duckdb.NullIf(clause.Column{Name: "age"}, 30)).Order("id").Scan(&rows).Error
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Nil(t, rows[1].Age)
+		require.NotNil(t, rows[2].Age)
+		assert.Equal(t, int64(35), *rows[2].Age)
+	})
+}
+
+type DeviceReading struct {
+	ID         uint `gorm:"primaryKey"`
+	DeviceID   string
+	Channel    int
+	Value      float64
+	RecordedAt time.Time
+}
+
+func TestDeduplicateLatest(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&DeviceReading{}))
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create(&[]DeviceReading{
+		{DeviceID: "a", Channel: 1, Value: 1, RecordedAt: base},
+		{DeviceID: "a", Channel: 1, Value: 2, RecordedAt: base.Add(2 * time.Hour)},
+		{DeviceID: "a", Channel: 2, Value: 3, RecordedAt: base.Add(time.Hour)},
+		{DeviceID: "b", Channel: 1, Value: 4, RecordedAt: base.Add(3 * time.Hour)},
+		{DeviceID: "b", Channel: 1, Value: 5, RecordedAt: base.Add(time.Hour)},
+	}).Error)
+
+	t.Run("LatestPerKey", func(t *testing.T) {
+		var latest []DeviceReading
+		err := duckdb.DeduplicateLatest(db, &DeviceReading{}, []string{"device_id"}, "recorded_at DESC").
+			Order("device_id").Find(&latest).Error
+		require.NoError(t, err)
+		require.Len(t, latest, 2)
+		assert.Equal(t, 2.0, latest[0].Value)
+		assert.Equal(t, 4.0, latest[1].Value)
+	})
+
+	t.Run("CompositeKey", func(t *testing.T) {
+		var latest []DeviceReading
+		err := duckdb.DeduplicateLatest(db, &DeviceReading{}, []string{"device_id", "channel"}, "recorded_at DESC").
+			Order("device_id, channel").Find(&latest).Error
+		require.NoError(t, err)
+		require.Len(t, latest, 3)
+		assert.Equal(t, []float64{2, 3, 4}, []float64{latest[0].Value, latest[1].Value, latest[2].Value})
+	})
+
+	t.Run("FilterBeforeAndAfter", func(t *testing.T) {
+		var latest []DeviceReading
+		err := duckdb.DeduplicateLatest(db.Where("recorded_at < ?", base.Add(2*time.Hour)), &DeviceReading{}, []string{"device_id"}, "recorded_at DESC").
+			Where("device_id = ?", "a").Find(&latest).Error
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, 3.0, latest[0].Value)
+	})
+}
+
+func TestFlattenJSON(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE nested_events (id INTEGER, payload JSON)").Error)
+	require.NoError(t, db.Exec(`INSERT INTO nested_events VALUES
+		(1, '{"user": {"name": "ann", "address": {"city": "Oslo", "zip": "0150"}}, "score": 42, "active": true}'),
+		(2, '{"user": {"name": "bob"}, "score": 7, "active": false}')`).Error)
+
+	type flatEvent struct {
+		ID     int
+		Name   string
+		City   *string
+		Zip    *string
+		Score  int
+		Active bool
+	}
+
+	fields := duckdb.FlattenJSON("payload", map[string]string{
+		"name":   "$.user.name",
+		"city":   "$.user.address.city",
+		"zip":    "/user/address/zip",
+		"score":  "$.score",
+		"active": "$.active",
+	})
+	require.Len(t, fields, 5)
+
+	var events []flatEvent
+	err := db.Table("nested_events").Select("id, ?", clause.CommaExpression{Exprs: fields}).Order("id").Scan(&events).Error
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "ann", events[0].Name)
+	require.NotNil(t, events[0].City)
+	assert.Equal(t, "Oslo", *events[0].City)
+	require.NotNil(t, events[0].Zip)
+	assert.Equal(t, "0150", *events[0].Zip)
+	assert.Equal(t, 42, events[0].Score)
+	assert.True(t, events[0].Active)
+
+	assert.Equal(t, "bob", events[1].Name)
+	assert.Nil(t, events[1].City, "missing paths are NULL")
+	assert.Nil(t, events[1].Zip)
+	assert.Equal(t, 7, events[1].Score)
+	assert.False(t, events[1].Active)
+}
+
+func TestCurrentDatabaseAndSchema(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+
+	database, err := duckdb.CurrentDatabase(db)
+	require.NoError(t, err)
+	assert.Equal(t, "memory", database)
+
+	schemaName, err := duckdb.CurrentSchema(db)
+	require.NoError(t, err)
+	assert.Equal(t, "main", schemaName)
+
+	require.NoError(t, db.Connection(func(tx *gorm.DB) error {
+		require.NoError(t, tx.Exec("ATTACH ':memory:' AS reporting").Error)
+		require.NoError(t, tx.Exec("CREATE SCHEMA reporting.staging").Error)
+		require.NoError(t, tx.Exec("USE reporting.staging").Error)
+
+		database, err := duckdb.CurrentDatabase(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "reporting", database)
+
+		schemaName, err := duckdb.CurrentSchema(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "staging", schemaName)
+		return nil
+	}))
+}
+
+type CatalogReport struct {
+	ID    uint `gorm:"primaryKey"`
+	Title string
+}
+
+func TestUse(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("ATTACH ':memory:' AS reporting").Error)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		require.NoError(t, duckdb.Use(tx, "reporting"))
+
+		database, err := duckdb.CurrentDatabase(tx)
+		require.NoError(t, err)
+		assert.Equal(t, "reporting", database)
+
+		require.NoError(t, tx.Exec("CREATE SEQUENCE seq_catalog_reports_id START 1").Error)
+		require.NoError(t, tx.Exec("CREATE TABLE catalog_reports (id INTEGER PRIMARY KEY DEFAULT nextval('seq_catalog_reports_id'), title VARCHAR)").Error)
+		require.NoError(t, tx.Create(&CatalogReport{Title: "quarterly"}).Error)
+
+		var count int64
+		require.NoError(t, tx.Model(&CatalogReport{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+
+		require.NoError(t, duckdb.Use(tx, "memory.main"))
+		return nil
+	}))
+
+	// The table was created in the attached catalog, not the default one
+	var titles []string
+	require.NoError(t, db.Table("reporting.main.catalog_reports").Pluck("title", &titles).Error)
+	assert.Equal(t, []string{"quarterly"}, titles)
+	var count int64
+	assert.Error(t, db.Table("memory.main.catalog_reports").Count(&count).Error)
+
+	assert.Error(t, duckdb.Use(db, "missing_catalog"))
+}
+
+func TestQuantileHelpers(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE latencies AS SELECT CAST(range AS DOUBLE) AS ms FROM range(1, 1001)").Error)
+
+	t.Run("ApproxQuantile", func(t *testing.T) {
+		var stats struct {
+			P50 float64
+			P95 float64
+		}
+		err := db.Table("latencies").
+			Select("? AS p50, ? AS p95", duckdb.ApproxQuantile("ms", 0.5), duckdb.ApproxQuantile("ms", 0.95)).
+			Scan(&stats).Error
+		require.NoError(t, err)
+		assert.InDelta(t, 500, stats.P50, 25)
+		assert.InDelta(t, 950, stats.P95, 25)
+	})
+
+	t.Run("ReservoirQuantile", func(t *testing.T) {
+		var median float64
+		err := db.Table("latencies").Select("?", duckdb.ReservoirQuantile("ms", 0.5)).Scan(&median).Error
+		require.NoError(t, err)
+		assert.InDelta(t, 500, median, 25)
+	})
+
+	t.Run("MultipleQuantiles", func(t *testing.T) {
+		var approx duckdb.FloatArray
+		err := db.Table("latencies").Select("?", duckdb.ApproxQuantiles("ms", 0.5, 0.95)).Row().Scan(&approx)
+		require.NoError(t, err)
+		require.Len(t, approx.Get(), 2)
+		assert.InDelta(t, 500, approx.Get()[0], 25)
+		assert.InDelta(t, 950, approx.Get()[1], 25)
+
+		var reservoir duckdb.FloatArray
+		err = db.Table("latencies").Select("?", duckdb.ReservoirQuantiles("ms", 0.5)).Row().Scan(&reservoir)
+		require.NoError(t, err)
+		require.Len(t, reservoir.Get(), 1)
+		assert.InDelta(t, 500, reservoir.Get()[0], 25)
+	})
+}
+
+type FilterOrder struct {
+	ID     uint `gorm:"primaryKey"`
+	Status string
+	Amount float64
+}
+
+func TestFilteredAggregates(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&FilterOrder{}))
+	require.NoError(t, db.Create(&[]FilterOrder{
+		{Status: "paid", Amount: 10},
+		{Status: "paid", Amount: 30},
+		{Status: "refunded", Amount: 20},
+		{Status: "pending", Amount: 5},
+	}).Error)
+
+	t.Run("Aliased", func(t *testing.T) {
+		var summary struct {
+			Paid     int64
+			Revenue  float64
+			AvgPaid  float64
+			Refunded int64
+		}
+		err := db.Model(&FilterOrder{}).Select(
+			duckdb.CountFilter("status = 'paid'").As("paid"),
+			duckdb.SumFilter("amount", "status = 'paid'").As("revenue"),
+			duckdb.AvgFilter("amount", "status = 'paid'").As("avg_paid"),
+			duckdb.CountFilter("status = 'refunded'").As("refunded"),
+		).Scan(&summary).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), summary.Paid)
+		assert.Equal(t, 40.0, summary.Revenue)
+		assert.Equal(t, 20.0, summary.AvgPaid)
+		assert.Equal(t, int64(1), summary.Refunded)
+	})
+
+	t.Run("Expression", func(t *testing.T) {
+		var rows []struct {
+			Paid  bool
+			Total float64
+		}
+		err := db.Model(&FilterOrder{}).
+			Select("status = 'paid' AS paid, ? AS total", duckdb.SumFilter("amount", "amount > 5")).
+			Group("paid").Order("paid").Scan(&rows).Error
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, 20.0, rows[0].Total)
+		assert.Equal(t, 40.0, rows[1].Total)
+	})
+}
+
+type JSONDocument struct {
+	ID   uint `gorm:"primaryKey"`
+	Data duckdb.JSON
+}
+
+func TestJSONPathHelpers(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&JSONDocument{}))
+	require.NoError(t, db.Create(&[]JSONDocument{
+		{Data: duckdb.JSON(`{"status": "active", "score": 5, "tags": ["go", "sql"], "owner": {"name": "ann"}}`)},
+		{Data: duckdb.JSON(`{"status": "inactive", "score": 7, "tags": ["rust"]}`)},
+		{},
+	}).Error)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var doc JSONDocument
+		require.NoError(t, db.First(&doc, 1).Error)
+		assert.JSONEq(t, `{"status": "active", "score": 5, "tags": ["go", "sql"], "owner": {"name": "ann"}}`, string(doc.Data))
+
+		var empty JSONDocument
+		require.NoError(t, db.First(&empty, 3).Error)
+		assert.Nil(t, empty.Data)
+	})
+
+	t.Run("Eq", func(t *testing.T) {
+		var docs []JSONDocument
+		require.NoError(t, db.Where(duckdb.JSONExtract("data", "$.status").Eq("active")).Find(&docs).Error)
+		require.Len(t, docs, 1)
+		assert.Equal(t, uint(1), docs[0].ID)
+
+		require.NoError(t, db.Where(duckdb.JSONExtract("data", "/score").Eq(7)).Find(&docs).Error)
+		require.Len(t, docs, 1)
+		assert.Equal(t, uint(2), docs[0].ID)
+
+		require.NoError(t, db.Where(duckdb.JSONExtract("data", "$.status").Neq("active")).Find(&docs).Error)
+		require.Len(t, docs, 1)
+		assert.Equal(t, uint(2), docs[0].ID)
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		var docs []JSONDocument
+		require.NoError(t, db.Where(duckdb.JSONContains("data", map[string]interface{}{"tags": []string{"go"}})).Find(&docs).Error)
+		require.Len(t, docs, 1)
+		assert.Equal(t, uint(1), docs[0].ID)
+
+		require.NoError(t, db.Where(duckdb.JSONContains("data", map[string]interface{}{"owner": map[string]string{"name": "bob"}})).Find(&docs).Error)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("SelectText", func(t *testing.T) {
+		var owners []struct{ Name *string }
+		err := db.Model(&JSONDocument{}).Select("? AS name", duckdb.JSONExtract("data", "$.owner.name").Text()).Order("id").Scan(&owners).Error
+		require.NoError(t, err)
+		require.Len(t, owners, 3)
+		require.NotNil(t, owners[0].Name)
+		assert.Equal(t, "ann", *owners[0].Name)
+		assert.Nil(t, owners[1].Name)
+	})
+}
+
+type TaggedLine struct {
+	ID      uint `gorm:"primaryKey"`
+	Message string
+	Tags    string
+}
+
+func TestStringHelpers(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TaggedLine{}))
+	require.NoError(t, db.Create(&[]TaggedLine{
+		{Message: "ERROR 500 upstream timeout", Tags: "api,gateway"},
+		{Message: "INFO request served", Tags: "api"},
+		{Message: "ERROR 404 missing asset", Tags: "cdn,static,assets"},
+	}).Error)
+
+	var rows []struct {
+		ID   uint
+		Code string
+		Tags duckdb.StringArray
+	}
+	require.NoError(t, db.Model(&TaggedLine{}).
+		Select("id, ? AS code, ? AS tags", duckdb.RegexpExtract("message", `^ERROR (\d+)`, 1), duckdb.StringSplit("tags", ",")).
+		Where(duckdb.RegexpMatches("message", `^ERROR \d+`)).
+		Order("id").
+		Scan(&rows).Error)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, "500", rows[0].Code)
+	assert.Equal(t, []string{"api", "gateway"}, rows[0].Tags.Get())
+	assert.Equal(t, "404", rows[1].Code)
+	assert.Equal(t, []string{"cdn", "static", "assets"}, rows[1].Tags.Get())
+
+	var unmatched string
+	require.NoError(t, db.Model(&TaggedLine{}).
+		Select("?", duckdb.RegexpExtract("message", `^ERROR (\d+)`, 1)).
+		Where("id = ?", 2).
+		Scan(&unmatched).Error)
+	assert.Empty(t, unmatched)
+}
@@ -186,4 +186,55 @@ func TestWorkaroundCompatibility(t *testing.T) {
 	})
 
 	t.Log("✅ Compatibility tests passed")
-}
\ No newline at end of file
+}
+
+func TestRowCallbackWorkaroundSessionOverride(t *testing.T) {
+	// Dialector disables the workaround; a session turns it back on
+	db, err := gorm.Open(OpenWithRowCallbackWorkaround(":memory:", false), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	enabled := db.Set(RowCallbackWorkaroundKey, true)
+	disabled := db.Set(RowCallbackWorkaroundKey, false)
+
+	if !rowCallbackWorkaroundEnabled(enabled) {
+		t.Fatal("Session setting true should enable the workaround")
+	}
+	if rowCallbackWorkaroundEnabled(disabled) {
+		t.Fatal("Session setting false should disable the workaround")
+	}
+	if rowCallbackWorkaroundEnabled(db) {
+		t.Fatal("Without a session setting the dialector config should apply")
+	}
+
+	row := enabled.Raw("SELECT 5").Row()
+	if row == nil {
+		t.Fatal("Session with the workaround enabled: Raw().Row() returned nil")
+	}
+	var result int
+	if err := row.Scan(&result); err != nil {
+		t.Fatalf("Failed to scan result: %v", err)
+	}
+	if result != 5 {
+		t.Fatalf("Expected result=5, got %d", result)
+	}
+
+	// GORM's default callback; with the GORM bug present Row() may be nil
+	if row := disabled.Raw("SELECT 5").Row(); row != nil {
+		result = 0
+		if err := row.Scan(&result); err != nil {
+			t.Fatalf("Failed to scan result: %v", err)
+		}
+		if result != 5 {
+			t.Fatalf("Expected result=5, got %d", result)
+		}
+	} else {
+		t.Log("Session with the workaround disabled: Raw().Row() returned nil (GORM bug present)")
+	}
+
+	// The override does not leak into the parent session
+	if rowCallbackWorkaroundEnabled(db) {
+		t.Fatal("Session setting leaked into the parent DB")
+	}
+}
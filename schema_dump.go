@@ -0,0 +1,184 @@
+package duckdb
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DumpSchema returns DDL recreating the schema of db's current database
+// without its data: CREATE SCHEMA, CREATE TYPE (enums), CREATE SEQUENCE,
+// CREATE TABLE, CREATE INDEX and CREATE VIEW statements, one per line. The
+// statements come from DuckDB's catalog (duckdb_tables() and friends) and
+// are ordered so that each object follows the ones it depends on: tables
+// follow the tables their foreign keys reference, and other objects of a
+// kind are written in creation order, so views follow the views they
+// select from. The
+// result can be executed as one script against a fresh database.
+func DumpSchema(db *gorm.DB) (string, error) {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	var statements []string
+
+	var schemas []string
+	if err := tx.Raw(`SELECT schema_name FROM duckdb_schemas()
+		WHERE database_name = current_database() AND NOT internal AND schema_name <> 'main'
+		ORDER BY oid`).Scan(&schemas).Error; err != nil {
+		return "", fmt.Errorf("failed to list schemas: %w", err)
+	}
+	for _, name := range schemas {
+		statements = append(statements, "CREATE SCHEMA "+sqlIdentifier(name)+";")
+	}
+
+	enums, err := dumpEnumTypes(tx)
+	if err != nil {
+		return "", err
+	}
+	statements = append(statements, enums...)
+
+	sequences, err := dumpCatalogSQL(tx, "sequences", `SELECT sql FROM duckdb_sequences()
+		WHERE database_name = current_database() AND NOT temporary ORDER BY sequence_oid`)
+	if err != nil {
+		return "", err
+	}
+	statements = append(statements, sequences...)
+
+	tables, err := dumpTables(tx)
+	if err != nil {
+		return "", err
+	}
+	statements = append(statements, tables...)
+
+	for _, object := range []struct{ kind, query string }{
+		{"indexes", `SELECT sql FROM duckdb_indexes()
+			WHERE database_name = current_database() AND sql IS NOT NULL ORDER BY index_oid`},
+		{"views", `SELECT sql FROM duckdb_views()
+			WHERE database_name = current_database() AND NOT internal AND NOT temporary ORDER BY view_oid`},
+	} {
+		ddl, err := dumpCatalogSQL(tx, object.kind, object.query)
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, ddl...)
+	}
+
+	if len(statements) == 0 {
+		return "", nil
+	}
+	return strings.Join(statements, "\n") + "\n", nil
+}
+
+// dumpCatalogSQL runs query, which selects the sql column of a catalog
+// function, and returns the terminated statements
+func dumpCatalogSQL(tx *gorm.DB, kind, query string) ([]string, error) {
+	var ddl []string
+	if err := tx.Raw(query).Scan(&ddl).Error; err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+	for i, statement := range ddl {
+		ddl[i] = terminateStatement(statement)
+	}
+	return ddl, nil
+}
+
+// dumpTables returns the CREATE TABLE statements in creation order, except
+// that a table is moved after the tables its foreign keys reference. Table
+// oids alone are not enough: DuckDB replaces a table's catalog entry, and
+// with it the oid, when an index or constraint is added later.
+func dumpTables(tx *gorm.DB) ([]string, error) {
+	var tables []struct {
+		SchemaName string
+		TableName  string
+		SQL        string `gorm:"column:sql"`
+	}
+	if err := tx.Raw(`SELECT schema_name, table_name, sql FROM duckdb_tables()
+		WHERE database_name = current_database() AND NOT internal AND NOT temporary
+		ORDER BY table_oid`).Scan(&tables).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	// Foreign keys cannot cross schemas, so references resolve in the
+	// referencing table's schema
+	var references []struct {
+		SchemaName      string
+		TableName       string
+		ReferencedTable string
+	}
+	if err := tx.Raw(`SELECT DISTINCT schema_name, table_name, referenced_table FROM duckdb_constraints()
+		WHERE database_name = current_database() AND constraint_type = 'FOREIGN KEY'`).Scan(&references).Error; err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	dependsOn := make(map[[2]string][][2]string)
+	for _, ref := range references {
+		table := [2]string{ref.SchemaName, ref.TableName}
+		dependsOn[table] = append(dependsOn[table], [2]string{ref.SchemaName, ref.ReferencedTable})
+	}
+
+	index := make(map[[2]string]int, len(tables))
+	for i, table := range tables {
+		index[[2]string{table.SchemaName, table.TableName}] = i
+	}
+
+	statements := make([]string, 0, len(tables))
+	visited := make([]bool, len(tables))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, dependency := range dependsOn[[2]string{tables[i].SchemaName, tables[i].TableName}] {
+			if j, ok := index[dependency]; ok {
+				visit(j)
+			}
+		}
+		statements = append(statements, terminateStatement(tables[i].SQL))
+	}
+	for i := range tables {
+		visit(i)
+	}
+	return statements, nil
+}
+
+// dumpEnumTypes returns a CREATE TYPE ... AS ENUM statement for each user
+// enum type. duckdb_types() has no DDL column, so it is rebuilt from
+// enum_range.
+func dumpEnumTypes(tx *gorm.DB) ([]string, error) {
+	var types []struct {
+		SchemaName string
+		TypeName   string
+	}
+	if err := tx.Raw(`SELECT schema_name, type_name FROM duckdb_types()
+		WHERE database_name = current_database() AND NOT internal AND logical_type = 'ENUM'
+		ORDER BY type_oid`).Scan(&types).Error; err != nil {
+		return nil, fmt.Errorf("failed to list enum types: %w", err)
+	}
+
+	statements := make([]string, 0, len(types))
+	for _, enum := range types {
+		name := sqlIdentifier(enum.TypeName)
+		if enum.SchemaName != schemaMain {
+			name = sqlIdentifier(enum.SchemaName) + "." + name
+		}
+
+		var values []string
+		if err := tx.Raw("SELECT CAST(unnest(enum_range(NULL::" + name + ")) AS VARCHAR)").Scan(&values).Error; err != nil {
+			return nil, fmt.Errorf("failed to read values of enum %s: %w", enum.TypeName, err)
+		}
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = sqlStringLiteral(value)
+		}
+		statements = append(statements, "CREATE TYPE "+name+" AS ENUM ("+strings.Join(literals, ", ")+");")
+	}
+	return statements, nil
+}
+
+// terminateStatement trims a catalog DDL statement and ends it with ";"
+func terminateStatement(statement string) string {
+	statement = strings.TrimSpace(statement)
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+	return statement
+}
@@ -0,0 +1,69 @@
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type DumpAuthor struct {
+	ID    uint   `gorm:"primaryKey"`
+	Email string `gorm:"uniqueIndex"`
+	Name  string `gorm:"index:idx_dump_author_name"`
+}
+
+func TestDumpSchema(t *testing.T) {
+	open := func() *gorm.DB {
+		db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		return db
+	}
+
+	source := open()
+	require.NoError(t, source.AutoMigrate(&DumpAuthor{}))
+	for _, ddl := range []string{
+		"CREATE SCHEMA archive",
+		"CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy')",
+		"CREATE SEQUENCE post_ids START 100",
+		"CREATE TABLE posts (id BIGINT DEFAULT nextval('post_ids') PRIMARY KEY, author_id INTEGER REFERENCES dump_authors(id), title VARCHAR NOT NULL, feeling mood)",
+		"CREATE TABLE archive.posts (id BIGINT, title VARCHAR)",
+		"CREATE INDEX idx_posts_title ON posts (title)",
+		"CREATE VIEW happy_posts AS SELECT * FROM posts WHERE feeling = 'happy'",
+		"CREATE VIEW happy_titles AS SELECT title FROM happy_posts",
+	} {
+		require.NoError(t, source.Exec(ddl).Error, ddl)
+	}
+	require.NoError(t, source.Exec("INSERT INTO dump_authors (id, email, name) VALUES (1, 'a@example.com', 'Ann')").Error)
+
+	dump, err := duckdb.DumpSchema(source)
+	require.NoError(t, err)
+	for _, fragment := range []string{"CREATE SCHEMA", "CREATE TYPE", "CREATE SEQUENCE", "CREATE TABLE", "CREATE INDEX", "CREATE UNIQUE INDEX", "CREATE VIEW"} {
+		assert.Contains(t, dump, fragment)
+	}
+	assert.NotContains(t, dump, "a@example.com", "the dump holds no data")
+
+	target := open()
+	require.NoError(t, target.Exec(dump).Error, dump)
+
+	redump, err := duckdb.DumpSchema(target)
+	require.NoError(t, err)
+	assert.Equal(t, dump, redump)
+
+	// The recreated schema works: sequence default, enum, foreign key and views
+	require.NoError(t, target.Exec("INSERT INTO dump_authors (id, email, name) VALUES (1, 'a@example.com', 'Ann')").Error)
+	require.NoError(t, target.Exec("INSERT INTO posts (author_id, title, feeling) VALUES (1, 'hello', 'happy')").Error)
+	assert.Error(t, target.Exec("INSERT INTO posts (author_id, title) VALUES (2, 'orphan')").Error)
+
+	var titles []string
+	require.NoError(t, target.Raw("SELECT title FROM happy_titles").Scan(&titles).Error)
+	assert.Equal(t, []string{"hello"}, titles)
+
+	var id int64
+	require.NoError(t, target.Raw("SELECT id FROM posts").Scan(&id).Error)
+	assert.Equal(t, int64(100), id)
+}
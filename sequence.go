@@ -0,0 +1,77 @@
+package duckdb
+
+import (
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// SequenceOptions configures a sequence created with CreateSequence. Zero
+// values leave DuckDB's defaults.
+type SequenceOptions struct {
+	// Start is the first value nextval returns.
+	// Default: 0 (MinValue for ascending sequences, MaxValue for descending)
+	Start int64
+
+	// Increment is added to the sequence on every nextval; negative values
+	// make a descending sequence.
+	// Default: 0 (1)
+	Increment int64
+
+	// MinValue and MaxValue bound the sequence.
+	// Default: nil (1 and the largest BIGINT for ascending sequences)
+	MinValue *int64
+	MaxValue *int64
+
+	// Cycle restarts the sequence at the opposite bound once one is reached,
+	// instead of failing nextval.
+	// Default: false
+	Cycle bool
+}
+
+// CreateSequence creates the sequence name, which may be schema-qualified.
+// Columns draw from it with a default tag, and several tables can share one:
+//
+//	duckdb.CreateSequence(db, "order_numbers", duckdb.SequenceOptions{Start: 1000})
+//
+//	type Order struct {
+//		Number int64 `gorm:"primaryKey;autoIncrement:false;default:nextval('order_numbers')"`
+//	}
+//
+// Create the sequence before migrating the tables that use it.
+func CreateSequence(db *gorm.DB, name string, opts SequenceOptions) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+
+	ddl := "CREATE SEQUENCE " + tx.Statement.Quote(name)
+	if opts.Increment != 0 {
+		ddl += " INCREMENT BY " + strconv.FormatInt(opts.Increment, 10)
+	}
+	if opts.MinValue != nil {
+		ddl += " MINVALUE " + strconv.FormatInt(*opts.MinValue, 10)
+	}
+	if opts.MaxValue != nil {
+		ddl += " MAXVALUE " + strconv.FormatInt(*opts.MaxValue, 10)
+	}
+	if opts.Start != 0 {
+		ddl += " START WITH " + strconv.FormatInt(opts.Start, 10)
+	}
+	if opts.Cycle {
+		ddl += " CYCLE"
+	}
+
+	if err := tx.Exec(ddl).Error; err != nil {
+		return fmt.Errorf("failed to create sequence %s: %w", name, err)
+	}
+	return nil
+}
+
+// DropSequence drops the sequence name if it exists. DuckDB refuses while a
+// column default still uses it.
+func DropSequence(db *gorm.DB, name string) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if err := tx.Exec("DROP SEQUENCE IF EXISTS " + tx.Statement.Quote(name)).Error; err != nil {
+		return fmt.Errorf("failed to drop sequence %s: %w", name, err)
+	}
+	return nil
+}
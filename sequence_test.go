@@ -0,0 +1,94 @@
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type SequencedInvoice struct {
+	Number   int64 `gorm:"primaryKey;default:nextval('document_numbers')"`
+	Customer string
+}
+
+type SequencedCreditNote struct {
+	Number int64 `gorm:"primaryKey;default:nextval('document_numbers')"`
+	Reason string
+}
+
+func TestSharedSequence(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	require.NoError(t, duckdb.CreateSequence(db, "document_numbers", duckdb.SequenceOptions{Start: 1000, Increment: 10}))
+	assert.Error(t, duckdb.CreateSequence(db, "document_numbers", duckdb.SequenceOptions{}), "sequence already exists")
+
+	require.NoError(t, db.AutoMigrate(&SequencedInvoice{}, &SequencedCreditNote{}))
+
+	invoice := SequencedInvoice{Customer: "acme"}
+	require.NoError(t, db.Create(&invoice).Error)
+	note := SequencedCreditNote{Reason: "refund"}
+	require.NoError(t, db.Create(&note).Error)
+	second := SequencedInvoice{Customer: "globex"}
+	require.NoError(t, db.Create(&second).Error)
+
+	assert.Equal(t, int64(1000), invoice.Number)
+	assert.Equal(t, int64(1010), note.Number)
+	assert.Equal(t, int64(1020), second.Number)
+
+	// The tables use the shared sequence rather than one of their own, and
+	// keep it when migrated again
+	require.NoError(t, db.AutoMigrate(&SequencedInvoice{}, &SequencedCreditNote{}))
+	var sequences []string
+	require.NoError(t, db.Raw("SELECT sequence_name FROM duckdb_sequences() ORDER BY sequence_name").Scan(&sequences).Error)
+	assert.Equal(t, []string{"document_numbers"}, sequences)
+
+	for _, model := range []interface{}{&SequencedInvoice{}, &SequencedCreditNote{}} {
+		columnTypes, err := db.Migrator().ColumnTypes(model)
+		require.NoError(t, err)
+		for _, columnType := range columnTypes {
+			if columnType.Name() == "number" {
+				defaultValue, ok := columnType.DefaultValue()
+				assert.True(t, ok)
+				assert.Equal(t, "nextval('document_numbers')", defaultValue)
+			}
+		}
+	}
+
+	batch := []SequencedCreditNote{{Reason: "damaged"}, {Reason: "late"}}
+	require.NoError(t, db.Create(&batch).Error)
+	assert.Equal(t, int64(1030), batch[0].Number)
+	assert.Equal(t, int64(1040), batch[1].Number)
+}
+
+func TestSequenceOptions(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	minValue, maxValue := int64(1), int64(3)
+	require.NoError(t, duckdb.CreateSequence(db, "dice", duckdb.SequenceOptions{MinValue: &minValue, MaxValue: &maxValue, Cycle: true}))
+
+	var rolls []int64
+	for i := 0; i < 4; i++ {
+		var roll int64
+		require.NoError(t, db.Raw("SELECT nextval('dice')").Scan(&roll).Error)
+		rolls = append(rolls, roll)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 1}, rolls)
+
+	floor, ceiling := int64(0), int64(10)
+	require.NoError(t, duckdb.CreateSequence(db, "countdown", duckdb.SequenceOptions{Start: 10, Increment: -5, MinValue: &floor, MaxValue: &ceiling}))
+	var countdown []int64
+	require.NoError(t, db.Raw("SELECT nextval('countdown') FROM range(3)").Scan(&countdown).Error)
+	assert.ElementsMatch(t, []int64{10, 5, 0}, countdown)
+	assert.Error(t, db.Exec("SELECT nextval('countdown')").Error, "exhausted without Cycle")
+
+	require.NoError(t, duckdb.DropSequence(db, "dice"))
+	assert.Error(t, db.Exec("SELECT nextval('dice')").Error)
+	require.NoError(t, duckdb.DropSequence(db, "dice"), "dropping a missing sequence is a no-op")
+}
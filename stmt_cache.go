@@ -0,0 +1,161 @@
+package duckdb
+
+import (
+	"container/list"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// stmtCache is a per-connection LRU of prepared statements keyed by query,
+// enabled with Config.StatementCacheSize. A cached statement is handed to
+// one caller at a time: Prepare checks it out and closing the returned
+// convertingStmt checks it back in instead of closing it, so a second
+// Prepare of a query whose statement is still in use (e.g. its rows are
+// open) prepares an uncached statement.
+//
+// The caches of one database share a generation counter; ClearStatementCache
+// bumps it and each cache drops its statements the next time it is used.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // of *cachedStmt, most recently used first
+	closed  bool
+
+	generation *atomic.Uint64 // shared by the database's caches; may be nil
+	seen       uint64         // generation the entries were prepared in
+}
+
+// cachedStmt is one statement in a stmtCache
+type cachedStmt struct {
+	query   string
+	stmt    driver.Stmt
+	inUse   bool
+	evicted bool // dropped from the cache while in use; closed on release
+}
+
+func newStmtCache(size int, generation *atomic.Uint64) *stmtCache {
+	c := &stmtCache{
+		size:       size,
+		entries:    make(map[string]*list.Element, size),
+		order:      list.New(),
+		generation: generation,
+	}
+	if generation != nil {
+		c.seen = generation.Load()
+	}
+	return c
+}
+
+// checkout returns the cached statement for query and marks it in use, or
+// nil if there is none or it is already in use.
+func (c *stmtCache) checkout(query string) *cachedStmt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncGeneration()
+
+	elem, ok := c.entries[query]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*cachedStmt)
+	if entry.inUse {
+		return nil
+	}
+	entry.inUse = true
+	c.order.MoveToFront(elem)
+	return entry
+}
+
+// add caches a freshly prepared stmt for query, checked out to the caller,
+// and closes the least recently used statements beyond the cache size. It
+// returns nil, leaving stmt uncached, when query already has an entry or
+// the cache is closed.
+func (c *stmtCache) add(query string, stmt driver.Stmt) *cachedStmt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncGeneration()
+
+	if _, ok := c.entries[query]; ok || c.closed {
+		return nil
+	}
+	entry := &cachedStmt{query: query, stmt: stmt, inUse: true}
+	c.entries[query] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		evicted := oldest.Value.(*cachedStmt)
+		delete(c.entries, evicted.query)
+		if evicted.inUse {
+			evicted.evicted = true
+		} else {
+			_ = evicted.stmt.Close()
+		}
+	}
+	return entry
+}
+
+// release checks entry back in, closing it if it was evicted meanwhile
+func (c *stmtCache) release(entry *cachedStmt) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.inUse = false
+	if entry.evicted {
+		return entry.stmt.Close()
+	}
+	return nil
+}
+
+// len returns the number of cached statements, in use or not
+func (c *stmtCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncGeneration()
+	return c.order.Len()
+}
+
+// close closes every cached statement; those in use are closed on release
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	return c.purge()
+}
+
+// syncGeneration drops every statement once ClearStatementCache has bumped
+// the shared generation. c.mu must be held.
+func (c *stmtCache) syncGeneration() {
+	if c.generation == nil {
+		return
+	}
+	if current := c.generation.Load(); current != c.seen {
+		c.seen = current
+		if err := c.purge(); err != nil {
+			debugLog(" failed to close invalidated statements: %v", err)
+		}
+	}
+}
+
+// purge empties the cache, closing idle statements now and marking those in
+// use to be closed on release. c.mu must be held.
+func (c *stmtCache) purge() error {
+	var errs []error
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*cachedStmt)
+		if entry.inUse {
+			entry.evicted = true
+			continue
+		}
+		if err := entry.stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,188 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// countingStmt is a driver.Stmt that only records Close calls
+type countingStmt struct {
+	driver.Stmt
+	closed int
+}
+
+func (s *countingStmt) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestStmtCacheEviction(t *testing.T) {
+	cache := newStmtCache(2, nil)
+	stmts := map[string]*countingStmt{"a": {}, "b": {}, "c": {}}
+
+	for _, query := range []string{"a", "b"} {
+		entry := cache.add(query, stmts[query])
+		if entry == nil {
+			t.Fatalf("add(%q) did not cache the statement", query)
+		}
+		if err := cache.release(entry); err != nil {
+			t.Fatalf("release(%q): %v", query, err)
+		}
+	}
+
+	// Using a makes b the least recently used statement
+	entry := cache.checkout("a")
+	if entry == nil || entry.stmt != stmts["a"] {
+		t.Fatal("checkout(a) did not return the cached statement")
+	}
+	if cache.checkout("a") != nil {
+		t.Fatal("checkout(a) handed out a statement already in use")
+	}
+	if err := cache.release(entry); err != nil {
+		t.Fatalf("release(a): %v", err)
+	}
+
+	entry = cache.add("c", stmts["c"])
+	if entry == nil {
+		t.Fatal("add(c) did not cache the statement")
+	}
+	if got := cache.len(); got != 2 {
+		t.Fatalf("cache holds %d statements, want 2", got)
+	}
+	if stmts["b"].closed != 1 {
+		t.Fatalf("evicted statement b closed %d times, want 1", stmts["b"].closed)
+	}
+	if stmts["a"].closed != 0 || stmts["c"].closed != 0 {
+		t.Fatal("statements still cached were closed")
+	}
+	if cache.checkout("b") != nil {
+		t.Fatal("evicted statement b is still cached")
+	}
+
+	// c is in use: evicting it defers Close to its release
+	cache.add("d", &countingStmt{})
+	cache.add("e", &countingStmt{})
+	if stmts["c"].closed != 0 {
+		t.Fatal("statement c was closed while in use")
+	}
+	if err := cache.release(entry); err != nil {
+		t.Fatalf("release(c): %v", err)
+	}
+	if stmts["c"].closed != 1 {
+		t.Fatalf("statement c closed %d times after release, want 1", stmts["c"].closed)
+	}
+}
+
+func TestStmtCacheGeneration(t *testing.T) {
+	generation := new(atomic.Uint64)
+	cache := newStmtCache(4, generation)
+	idle, busy := &countingStmt{}, &countingStmt{}
+
+	if err := cache.release(cache.add("idle", idle)); err != nil {
+		t.Fatalf("release(idle): %v", err)
+	}
+	busyEntry := cache.add("busy", busy)
+
+	generation.Add(1)
+	if got := cache.len(); got != 0 {
+		t.Fatalf("cache holds %d statements after invalidation, want 0", got)
+	}
+	if idle.closed != 1 {
+		t.Fatalf("idle statement closed %d times, want 1", idle.closed)
+	}
+	if busy.closed != 0 {
+		t.Fatal("statement in use was closed by invalidation")
+	}
+	if err := cache.release(busyEntry); err != nil {
+		t.Fatalf("release(busy): %v", err)
+	}
+	if busy.closed != 1 {
+		t.Fatalf("busy statement closed %d times after release, want 1", busy.closed)
+	}
+
+	if err := cache.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if cache.add("late", &countingStmt{}) != nil {
+		t.Fatal("closed cache accepted a statement")
+	}
+}
+
+// connStmtCache runs fn with the stmtCache of db's single connection
+func connStmtCache(t *testing.T, db *gorm.DB, fn func(cache *stmtCache)) {
+	t.Helper()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database: %v", err)
+	}
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get connection: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn interface{}) error {
+		fn(driverConn.(*convertingConn).stmtCache)
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to inspect connection: %v", err)
+	}
+}
+
+func TestStatementCacheOnConnection(t *testing.T) {
+	db, err := gorm.Open(OpenWithConfig(":memory:", &Config{StatementCacheSize: 2}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	if err := db.Exec("CREATE TABLE cache_rows (id INTEGER, code INTEGER)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	queries := []string{
+		"SELECT code FROM cache_rows WHERE id = ?",
+		"SELECT id FROM cache_rows WHERE code = ?",
+		"SELECT count(*) FROM cache_rows WHERE id > ?",
+	}
+	for _, query := range queries {
+		stmt, err := sqlDB.Prepare(query)
+		if err != nil {
+			t.Fatalf("Failed to prepare %q: %v", query, err)
+		}
+		stmt.Close()
+	}
+
+	connStmtCache(t, db, func(cache *stmtCache) {
+		if got := cache.len(); got != 2 {
+			t.Fatalf("cache holds %d statements, want 2", got)
+		}
+		if cache.checkout(queries[0]) != nil {
+			t.Fatal("least recently used statement was not evicted")
+		}
+		for _, query := range queries[1:] {
+			entry := cache.checkout(query)
+			if entry == nil {
+				t.Fatalf("statement for %q is not cached", query)
+			}
+			cache.release(entry)
+		}
+	})
+
+	ClearStatementCache(db)
+	connStmtCache(t, db, func(cache *stmtCache) {
+		if got := cache.len(); got != 0 {
+			t.Fatalf("cache holds %d statements after ClearStatementCache, want 0", got)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// StreamOptions configures Stream
+type StreamOptions struct {
+	// Reuse scans every row into the same T and yields the same pointer each
+	// iteration, so the yielded struct, including values behind its pointer
+	// fields, is overwritten by the next row; copy what you keep. Scan
+	// buffers are allocated once, which cuts per-row allocations for large
+	// reads.
+	Reuse bool
+}
+
+// Stream runs the query built on tx and yields its rows one at a time as *T,
+// without loading the result into memory. The model defaults to T when tx
+// has no Model or Table. Iteration stops at the first error, which is
+// yielded with a nil row:
+//
+//	for reading, err := range duckdb.Stream[Reading](db.Where("day = ?", day), nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Pass nil options for the defaults.
+func Stream[T any](tx *gorm.DB, options *StreamOptions) iter.Seq2[*T, error] {
+	if options == nil {
+		options = &StreamOptions{}
+	}
+
+	return func(yield func(*T, error) bool) {
+		if tx.Statement.Model == nil && tx.Statement.Table == "" {
+			tx = tx.Model(new(T))
+		}
+
+		rows, err := tx.Rows()
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to run query for stream: %w", err))
+			return
+		}
+		defer rows.Close()
+
+		var item *T
+		scan := func() error {
+			item = new(T)
+			return tx.ScanRows(rows, item)
+		}
+		if options.Reuse {
+			item = new(T)
+			if scan, err = reusableScan(tx, rows, item); err != nil {
+				yield(nil, fmt.Errorf("failed to prepare stream: %w", err))
+				return
+			}
+		}
+
+		for rows.Next() {
+			if err := scan(); err != nil {
+				yield(nil, fmt.Errorf("failed to scan streamed row: %w", err))
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to read streamed rows: %w", err))
+		}
+	}
+}
+
+// reusableScan returns a function that scans the current row of rows into
+// item through buffers allocated once, matching columns to item's fields
+func reusableScan[T any](tx *gorm.DB, rows *sql.Rows, item *T) (func() error, error) {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(item); err != nil {
+		return nil, fmt.Errorf("failed to parse %T: %w", item, err)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result columns: %w", err)
+	}
+
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil || !field.Readable {
+			continue
+		}
+		// Serializer fields decode through GORM's scanner
+		if field.Serializer != nil {
+			return func() error { return tx.ScanRows(rows, item) }, nil
+		}
+		fields[i] = field
+	}
+
+	values := make([]interface{}, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	target := reflect.ValueOf(item).Elem()
+	ctx := tx.Statement.Context
+
+	return func() error {
+		if err := rows.Scan(targets...); err != nil {
+			return err
+		}
+		for i, field := range fields {
+			if field == nil {
+				continue
+			}
+			if err := field.Set(ctx, target, values[i]); err != nil {
+				return fmt.Errorf("failed to set %s: %w", field.Name, err)
+			}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,136 @@
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type StreamReading struct {
+	ID      uint `gorm:"primaryKey"`
+	Sensor  string
+	Value   float64
+	Note    *string
+	TakenAt time.Time
+}
+
+func setupStreamTestDB(tb testing.TB, rows int) *gorm.DB {
+	tb.Helper()
+
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(tb, err)
+	require.NoError(tb, db.AutoMigrate(&StreamReading{}))
+	require.NoError(tb, db.Exec(`INSERT INTO stream_readings (id, sensor, value, note, taken_at)
+		SELECT i, CASE WHEN i % 10 = 0 THEN NULL ELSE 's' || (i % 3) END, i / 2,
+			CASE WHEN i % 2 = 0 THEN 'even' END, TIMESTAMP '2025-01-01' + to_seconds(i)
+		FROM range(1, ?) t(i)`, rows+1).Error)
+	return db
+}
+
+func TestStream(t *testing.T) {
+	db := setupStreamTestDB(t, 100)
+
+	t.Run("Default", func(t *testing.T) {
+		var readings []*StreamReading
+		for reading, err := range duckdb.Stream[StreamReading](db.Where("id <= ?", 10).Order("id"), nil) {
+			require.NoError(t, err)
+			readings = append(readings, reading)
+		}
+		require.Len(t, readings, 10)
+		assert.Equal(t, uint(1), readings[0].ID)
+		assert.Equal(t, "s1", readings[0].Sensor)
+		assert.Nil(t, readings[0].Note)
+		require.NotNil(t, readings[1].Note)
+		assert.Equal(t, "even", *readings[1].Note)
+		assert.Equal(t, "", readings[9].Sensor, "NULL scans as the zero value")
+		assert.True(t, time.Date(2025, 1, 1, 0, 0, 3, 0, time.UTC).Equal(readings[2].TakenAt))
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		var first *StreamReading
+		var copies []StreamReading
+		for reading, err := range duckdb.Stream[StreamReading](db.Order("id"), &duckdb.StreamOptions{Reuse: true}) {
+			require.NoError(t, err)
+			if first == nil {
+				first = reading
+			}
+			assert.Same(t, first, reading, "the same struct is yielded every row")
+			copies = append(copies, *reading)
+		}
+		require.Len(t, copies, 100)
+		assert.Equal(t, uint(1), copies[0].ID)
+		assert.Nil(t, copies[0].Note)
+		require.NotNil(t, copies[1].Note)
+		assert.Equal(t, "even", *copies[1].Note)
+		assert.Equal(t, "", copies[9].Sensor)
+		assert.Equal(t, "s2", copies[10].Sensor)
+		assert.InDelta(t, 50.0, copies[99].Value, 0.0001)
+		assert.True(t, time.Date(2025, 1, 1, 0, 1, 40, 0, time.UTC).Equal(copies[99].TakenAt))
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		seen := 0
+		for _, err := range duckdb.Stream[StreamReading](db, nil) {
+			require.NoError(t, err)
+			seen++
+			if seen == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, seen)
+
+		// The connection was released when iteration stopped
+		var count int64
+		require.NoError(t, db.Model(&StreamReading{}).Count(&count).Error)
+		assert.Equal(t, int64(100), count)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var errs []error
+		for reading, err := range duckdb.Stream[StreamReading](db.Where("missing_column = 1"), nil) {
+			assert.Nil(t, reading)
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.Error(t, errs[0])
+	})
+}
+
+func BenchmarkStream(b *testing.B) {
+	db := setupStreamTestDB(b, 10000)
+
+	b.Run("Find", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var readings []StreamReading
+			if err := db.Find(&readings).Error; err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	for _, reuse := range []bool{false, true} {
+		name := "Stream"
+		if reuse {
+			name = "StreamReuse"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, err := range duckdb.Stream[StreamReading](db, &duckdb.StreamOptions{Reuse: reuse}) {
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/marcboeker/go-duckdb/v2"
+)
+
+// StructList scans a DuckDB LIST of STRUCTs (e.g. list(struct_pack(...)) or
+// array_agg of a row) into a Go slice of structs. It lets a parent and its
+// children be fetched in one aggregated query instead of N+1 lookups or a
+// Preload:
+//
+//	type Order struct {
+//		ID    uint
+//		Items duckdb.StructList[OrderItem] `gorm:"->;-:migration"`
+//	}
+//
+//	db.Raw(`SELECT o.id, list(struct_pack(sku := i.sku, unit_price := i.price)) AS items
+//		FROM orders o JOIN order_items i ON i.order_id = o.id GROUP BY o.id`).Scan(&orders)
+//
+// STRUCT keys are matched to fields by `mapstructure` tag, then by name
+// ignoring case and underscores, so unit_price fills UnitPrice. Nested
+// structs and lists are decoded recursively. StructList is a read-side type:
+// tag the field `->;-:migration` since its shape is defined by the query.
+type StructList[T any] []T
+
+// GormDataType implements the GormDataTypeInterface for StructList
+func (StructList[T]) GormDataType() string {
+	return "STRUCT[]"
+}
+
+// Scan implements sql.Scanner interface for StructList
+func (l *StructList[T]) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("cannot scan %T into StructList, expected a DuckDB LIST", value)
+	}
+
+	result := make([]T, 0, len(items))
+	if err := decodeNested(items, &result); err != nil {
+		return fmt.Errorf("failed to decode LIST into StructList: %w", err)
+	}
+
+	*l = result
+	return nil
+}
+
+// matchStructFieldName compares a DuckDB STRUCT key with a Go field name,
+// ignoring case and underscores so snake_case keys map onto CamelCase fields.
+func matchStructFieldName(mapKey, fieldName string) bool {
+	return strings.EqualFold(strings.ReplaceAll(mapKey, "_", ""), strings.ReplaceAll(fieldName, "_", ""))
+}
+
+// NestedScanner scans an arbitrarily nested DuckDB value, MAPs, LISTs and
+// STRUCTs in any combination, into the Go value Target points to, e.g. a
+// MAP(VARCHAR, INTEGER[]) into a map[string][]int64 or a
+// LIST(MAP(VARCHAR, DOUBLE)) into a []map[string]float64:
+//
+//	var tags map[string][]int64
+//	db.Raw("SELECT tag_ids FROM posts WHERE id = ?", id).Row().Scan(&duckdb.NestedScanner{Target: &tags})
+//
+// Numbers convert between widths, and STRUCT keys match fields as in
+// StructList. A NULL leaves Target at its zero value.
+type NestedScanner struct {
+	Target interface{} // Pointer to the destination
+}
+
+// Scan implements sql.Scanner for NestedScanner
+func (ns *NestedScanner) Scan(value interface{}) error {
+	target := reflect.ValueOf(ns.Target)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", ns.Target)
+	}
+
+	// Decoding merges into maps, so start from a clean value
+	target.Elem().SetZero()
+	if value == nil {
+		return nil
+	}
+	if err := decodeNested(value, ns.Target); err != nil {
+		return fmt.Errorf("failed to decode %T into %T: %w", value, ns.Target, err)
+	}
+	return nil
+}
+
+// decodeNested decodes a value scanned from DuckDB into result, converting
+// MAP keys to strings at every level
+func decodeNested(value interface{}, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           result,
+		WeaklyTypedInput: true,
+		MatchName:        matchStructFieldName,
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(nestedScannerHook, nestedNumberHook),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	return decoder.Decode(normalizeJSONValue(value))
+}
+
+// nestedScannerHook lets nested sql.Scanner types, such as a Struct inside a
+// Struct, scan their own part of the value
+func nestedScannerHook(_ reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	target := reflect.New(to)
+	scanner, ok := target.Interface().(sql.Scanner)
+	if !ok || reflect.TypeOf(data) == to {
+		return data, nil
+	}
+	if err := scanner.Scan(data); err != nil {
+		return nil, err
+	}
+	return target.Elem().Interface(), nil
+}
+
+// nestedNumberHook converts DECIMAL and HUGEINT values, which go-duckdb
+// scans as duckdb.Decimal and *big.Int, to the numeric or string kind of
+// the destination
+func nestedNumberHook(_ reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	switch v := data.(type) {
+	case duckdb.Decimal:
+		if to.Kind() == reflect.String {
+			return v.String(), nil
+		}
+		return v.Float64(), nil
+	case *big.Int:
+		switch to.Kind() {
+		case reflect.String:
+			return v.String(), nil
+		case reflect.Float32, reflect.Float64:
+			f, _ := new(big.Float).SetInt(v).Float64()
+			return f, nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !v.IsInt64() {
+				return nil, fmt.Errorf("HUGEINT %s overflows %s", v, to)
+			}
+			return v.Int64(), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !v.IsUint64() {
+				return nil, fmt.Errorf("HUGEINT %s overflows %s", v, to)
+			}
+			return v.Uint64(), nil
+		}
+	}
+	return data, nil
+}
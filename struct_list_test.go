@@ -0,0 +1,154 @@
+package duckdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type NestedOrder struct {
+	ID       uint `gorm:"primaryKey"`
+	Customer string
+}
+
+type NestedOrderItem struct {
+	ID        uint `gorm:"primaryKey"`
+	OrderID   uint
+	SKU       string
+	UnitPrice float64
+	Quantity  int
+	ShippedAt time.Time
+}
+
+func TestStructList_ScanAggregatedChildren(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&NestedOrder{}, &NestedOrderItem{}))
+
+	shipped := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	orders := []NestedOrder{{Customer: "alice"}, {Customer: "bob"}}
+	for i := range orders {
+		require.NoError(t, db.Create(&orders[i]).Error)
+	}
+	items := []NestedOrderItem{
+		{OrderID: orders[0].ID, SKU: "A-1", UnitPrice: 9.5, Quantity: 2, ShippedAt: shipped},
+		{OrderID: orders[0].ID, SKU: "A-2", UnitPrice: 1.25, Quantity: 4, ShippedAt: shipped},
+		{OrderID: orders[1].ID, SKU: "B-1", UnitPrice: 100, Quantity: 1, ShippedAt: shipped},
+	}
+	for i := range items {
+		require.NoError(t, db.Create(&items[i]).Error)
+	}
+
+	type orderWithItems struct {
+		ID       uint
+		Customer string
+		Items    duckdb.StructList[NestedOrderItem]
+	}
+
+	var results []orderWithItems
+	err := db.Raw(`
+		SELECT o.id, o.customer,
+			list(struct_pack(sku := i.sku, unit_price := i.unit_price, quantity := i.quantity, shipped_at := i.shipped_at) ORDER BY i.sku) AS items
+		FROM nested_orders o
+		JOIN nested_order_items i ON i.order_id = o.id
+		GROUP BY o.id, o.customer
+		ORDER BY o.id
+	`).Scan(&results).Error
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "alice", results[0].Customer)
+	require.Len(t, results[0].Items, 2)
+	assert.Equal(t, "A-1", results[0].Items[0].SKU)
+	assert.InDelta(t, 9.5, results[0].Items[0].UnitPrice, 0.0001)
+	assert.Equal(t, 2, results[0].Items[0].Quantity)
+	assert.True(t, shipped.Equal(results[0].Items[0].ShippedAt))
+	assert.Equal(t, "A-2", results[0].Items[1].SKU)
+
+	assert.Equal(t, "bob", results[1].Customer)
+	require.Len(t, results[1].Items, 1)
+	assert.Equal(t, "B-1", results[1].Items[0].SKU)
+}
+
+func TestStructList_Scan(t *testing.T) {
+	type child struct {
+		Name string
+		Tags []string
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		list := duckdb.StructList[child]{{Name: "stale"}}
+		require.NoError(t, list.Scan(nil))
+		assert.Nil(t, list)
+	})
+
+	t.Run("NestedList", func(t *testing.T) {
+		var list duckdb.StructList[child]
+		err := list.Scan([]interface{}{
+			map[string]interface{}{"name": "a", "tags": []interface{}{"x", "y"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "a", list[0].Name)
+		assert.Equal(t, []string{"x", "y"}, list[0].Tags)
+	})
+
+	t.Run("NotAList", func(t *testing.T) {
+		var list duckdb.StructList[child]
+		assert.Error(t, list.Scan("not a list"))
+	})
+}
+
+func TestNestedScanner(t *testing.T) {
+	db := setupQueryHelpersTestDB(t)
+
+	t.Run("MapOfLists", func(t *testing.T) {
+		var tags map[string][]int64
+		err := db.Raw("SELECT MAP {'a': [1, 2, 3], 'b': [], 'c': [4]}::MAP(VARCHAR, INTEGER[])").Row().
+			Scan(&duckdb.NestedScanner{Target: &tags})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]int64{"a": {1, 2, 3}, "b": {}, "c": {4}}, tags)
+	})
+
+	t.Run("ListOfMaps", func(t *testing.T) {
+		var scores []map[string]float64
+		err := db.Raw("SELECT [MAP {'x': 1.5}, MAP {'x': 2.0, 'y': 3.25}]").Row().
+			Scan(&duckdb.NestedScanner{Target: &scores})
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]float64{{"x": 1.5}, {"x": 2, "y": 3.25}}, scores)
+	})
+
+	t.Run("MapOfStructs", func(t *testing.T) {
+		type dimensions struct {
+			Width  int
+			Height int
+		}
+		var sizes map[int]dimensions
+		err := db.Raw("SELECT MAP {1: {'width': 2, 'height': 3}, 2: {'width': 4, 'height': 5}}").Row().
+			Scan(&duckdb.NestedScanner{Target: &sizes})
+		require.NoError(t, err)
+		assert.Equal(t, map[int]dimensions{1: {2, 3}, 2: {4, 5}}, sizes)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		tags := map[string][]int64{"stale": {1}}
+		err := db.Raw("SELECT NULL::MAP(VARCHAR, INTEGER[])").Row().Scan(&duckdb.NestedScanner{Target: &tags})
+		require.NoError(t, err)
+		assert.Nil(t, tags)
+	})
+
+	t.Run("MapTypeNested", func(t *testing.T) {
+		var m duckdb.MapType
+		require.NoError(t, db.Raw("SELECT MAP {'outer': MAP {1: 'one'}}").Row().Scan(&m))
+		assert.Equal(t, map[string]interface{}{"1": "one"}, m["outer"])
+	})
+
+	t.Run("SimpleArrayScannerNested", func(t *testing.T) {
+		var groups [][]string
+		require.NoError(t, db.Raw("SELECT [['a', 'b'], [], ['c']]").Row().Scan(&duckdb.SimpleArrayScanner{Target: &groups}))
+		assert.Equal(t, [][]string{{"a", "b"}, {}, {"c"}}, groups)
+	})
+}
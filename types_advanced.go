@@ -1,13 +1,25 @@
 package duckdb
 
 import (
+	"bytes"
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
 )
 
 // Constants for repeated strings
@@ -146,6 +158,116 @@ func (StructType) GormDataType() string {
 	return "STRUCT"
 }
 
+// Struct is a DuckDB STRUCT column holding a Go struct, e.g.
+// Address duckdb.Struct[Address] migrates as a single
+// STRUCT("city" VARCHAR(256), "zip_code" BIGINT) column. Field names and
+// types come from T's GORM schema, so the DB's NamingStrategy and field tags
+// apply; nest further structs as Struct fields. Scanned keys are matched to
+// fields as in StructList.
+type Struct[T any] struct {
+	duckdb.Composite[T]
+}
+
+// NewStruct creates a Struct holding value
+func NewStruct[T any](value T) Struct[T] {
+	var s Struct[T]
+	_ = s.Composite.Scan(value)
+	return s
+}
+
+// GormDataType implements the GormDataTypeInterface for Struct
+func (Struct[T]) GormDataType() string {
+	return "STRUCT"
+}
+
+// GormDBDataType returns the STRUCT type for T, using the dialector's type
+// for each field
+func (Struct[T]) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	fields, err := structFields[T](db)
+	if err != nil {
+		return ""
+	}
+
+	var sql strings.Builder
+	sql.WriteString("STRUCT(")
+	for i, field := range fields {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		db.Dialector.QuoteTo(&sql, field.DBName)
+		sql.WriteString(" ")
+		if typer, ok := reflect.New(field.IndirectFieldType).Interface().(migrator.GormDataTypeInterface); ok {
+			sql.WriteString(typer.GormDBDataType(db, field))
+		} else {
+			sql.WriteString(db.Dialector.DataTypeOf(field))
+		}
+	}
+	sql.WriteString(")")
+	return sql.String()
+}
+
+// GormValue implements gorm.Valuer, writing the struct with struct_pack
+func (s Struct[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	fields, err := structFields[T](db)
+	if err != nil {
+		_ = db.AddError(err)
+		return clause.Expr{SQL: nullValue}
+	}
+
+	value := s.Get()
+	rv := reflect.Indirect(reflect.ValueOf(&value))
+
+	var sql strings.Builder
+	vars := make([]interface{}, 0, len(fields))
+	sql.WriteString("struct_pack(")
+	for i, field := range fields {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		db.Dialector.QuoteTo(&sql, field.DBName)
+		sql.WriteString(" := ?")
+
+		fieldValue := field.ReflectValueOf(ctx, rv)
+		if fieldValue.Kind() == reflect.Map {
+			vars = append(vars, mapLiteralExpr(fieldValue))
+		} else {
+			vars = append(vars, listValue(fieldValue.Interface()))
+		}
+	}
+	sql.WriteString(")")
+	return clause.Expr{SQL: sql.String(), Vars: vars}
+}
+
+// Scan implements sql.Scanner for Struct
+func (s *Struct[T]) Scan(value interface{}) error {
+	var result T
+	if value != nil {
+		if err := decodeNested(value, &result); err != nil {
+			return fmt.Errorf("failed to scan %T into %T: %w", value, s, err)
+		}
+	}
+	if err := s.Composite.Scan(result); err != nil {
+		return fmt.Errorf("failed to scan struct: %w", err)
+	}
+	return nil
+}
+
+// structFields returns the columns of T's GORM schema
+func structFields[T any](db *gorm.DB) ([]*schema.Field, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, fmt.Errorf("failed to parse struct %T: %w", *new(T), err)
+	}
+
+	fields := make([]*schema.Field, 0, len(stmt.Schema.Fields))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
 // ===== MAP TYPES =====
 
 // MapType represents a DuckDB MAP type - key-value pairs with typed keys and values
@@ -186,7 +308,11 @@ func (m *MapType) Scan(value interface{}) error {
 	case []byte:
 		return m.scanFromString(string(v))
 	case map[string]interface{}:
-		*m = MapType(v)
+		*m = MapType(normalizeJSONValue(v).(map[string]interface{}))
+		return nil
+	case duckdb.Map:
+		// Native MAP columns arrive with untyped keys, also in nested MAPs
+		*m = MapType(normalizeJSONValue(v).(map[string]interface{}))
 		return nil
 	default:
 		jsonBytes, err := json.Marshal(value)
@@ -254,6 +380,137 @@ func (MapType) GormDataType() string {
 	return "MAP(VARCHAR, VARCHAR)"
 }
 
+// GormValue implements gorm.Valuer so Create and Update write MapType as a
+// MAP literal with bound keys and values; DuckDB casts them to the column's
+// key and value types.
+func (m MapType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if m == nil {
+		return clause.Expr{SQL: emptyMap}
+	}
+	return mapLiteralExpr(reflect.ValueOf(m))
+}
+
+// mapLiteralExpr renders a Go map as a DuckDB MAP([keys...], [values...])
+// expression. go-duckdb cannot bind maps as parameters, so each key and value
+// is bound separately; the two-list form is used because DuckDB numbers the
+// parameters of a MAP {k: v} literal keys-first. Keys are sorted to keep the
+// SQL stable for statement caching.
+func mapLiteralExpr(rv reflect.Value) clause.Expr {
+	if rv.IsNil() {
+		return clause.Expr{SQL: nullValue}
+	}
+	if rv.Len() == 0 {
+		return clause.Expr{SQL: emptyMap}
+	}
+
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+	vars := make([]interface{}, 0, len(keys)*2)
+	for _, key := range keys {
+		vars = append(vars, listValue(key.Interface()))
+	}
+	for _, key := range keys {
+		vars = append(vars, listValue(rv.MapIndex(key).Interface()))
+	}
+
+	return clause.Expr{SQL: "MAP([" + placeholders + "], [" + placeholders + "])", Vars: vars}
+}
+
+// Map is a DuckDB MAP(K, V) column with typed keys and values, e.g.
+// Attributes duckdb.Map[string, string] migrates as MAP(VARCHAR, VARCHAR) and
+// Counters duckdb.Map[string, int32] as MAP(VARCHAR, INTEGER). A nil map is
+// stored as NULL and an empty map as MAP {}; both scan back the same way.
+type Map[K comparable, V any] struct {
+	duckdb.Composite[map[K]V]
+}
+
+// NewMap creates a Map holding values
+func NewMap[K comparable, V any](values map[K]V) Map[K, V] {
+	var m Map[K, V]
+	_ = m.Composite.Scan(values)
+	return m
+}
+
+// GormDataType returns the MAP type for K and V
+func (Map[K, V]) GormDataType() string {
+	return "MAP(" + duckdbTypeOf(reflect.TypeOf((*K)(nil)).Elem()) + ", " +
+		duckdbTypeOf(reflect.TypeOf((*V)(nil)).Elem()) + ")"
+}
+
+// GormValue implements gorm.Valuer, writing the map as a MAP literal with
+// bound keys and values
+func (m Map[K, V]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return mapLiteralExpr(reflect.ValueOf(m.Get()))
+}
+
+// Scan implements sql.Scanner for Map
+func (m *Map[K, V]) Scan(value interface{}) error {
+	if value == nil {
+		*m = Map[K, V]{}
+		return nil
+	}
+
+	result := make(map[K]V)
+	if err := decodeNested(value, &result); err != nil {
+		return fmt.Errorf("failed to scan %T into %T: %w", value, m, err)
+	}
+	if err := m.Composite.Scan(result); err != nil {
+		return fmt.Errorf("failed to scan map: %w", err)
+	}
+	return nil
+}
+
+// duckdbTypeOf returns the DuckDB type Go values of type t are stored as in
+// nested types; types without a direct equivalent are stored as VARCHAR
+func duckdbTypeOf(t reflect.Type) string {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return "TIMESTAMP"
+	case reflect.TypeOf(&big.Int{}):
+		return "HUGEINT"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8:
+		return "TINYINT"
+	case reflect.Int16:
+		return "SMALLINT"
+	case reflect.Int32:
+		return sqlTypeInteger
+	case reflect.Int, reflect.Int64:
+		return "BIGINT"
+	case reflect.Uint8:
+		return "UTINYINT"
+	case reflect.Uint16:
+		return "USMALLINT"
+	case reflect.Uint32:
+		return "UINTEGER"
+	case reflect.Uint, reflect.Uint64:
+		return "UBIGINT"
+	case reflect.Float32:
+		return "FLOAT"
+	case reflect.Float64:
+		return "DOUBLE"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return dataTypeBlob
+		}
+		return duckdbTypeOf(t.Elem()) + "[]"
+	case reflect.Map:
+		return "MAP(" + duckdbTypeOf(t.Key()) + ", " + duckdbTypeOf(t.Elem()) + ")"
+	case reflect.Ptr:
+		return duckdbTypeOf(t.Elem())
+	default:
+		return "VARCHAR"
+	}
+}
+
 // ===== LIST TYPES (Dynamic Arrays) =====
 
 // ListType represents a DuckDB LIST type - dynamic arrays with variable element types
@@ -467,6 +724,11 @@ func NewInterval(years, months, days, hours, minutes, seconds, micros int) Inter
 
 // Value implements driver.Valuer interface for IntervalType
 func (i IntervalType) Value() (driver.Value, error) {
+	return "INTERVAL '" + i.literal() + "'", nil
+}
+
+// literal renders the interval in DuckDB's textual form, e.g. "1 DAY 2 HOUR"
+func (i IntervalType) literal() string {
 	var parts []string
 
 	if i.Years != 0 {
@@ -492,10 +754,10 @@ func (i IntervalType) Value() (driver.Value, error) {
 	}
 
 	if len(parts) == 0 {
-		return "INTERVAL '0 SECOND'", nil
+		return "0 SECOND"
 	}
 
-	return "INTERVAL '" + strings.Join(parts, " ") + "'", nil
+	return strings.Join(parts, " ")
 }
 
 // Scan implements sql.Scanner interface for IntervalType
@@ -512,11 +774,31 @@ func (i *IntervalType) Scan(value interface{}) error {
 		return i.parseInterval(string(v))
 	case time.Duration:
 		return i.fromDuration(v)
+	case duckdb.Interval:
+		i.fromDuckDBInterval(v)
+		return nil
 	default:
 		return fmt.Errorf("cannot scan %T into IntervalType", value)
 	}
 }
 
+// fromDuckDBInterval splits go-duckdb's months/days/micros representation
+// into the interval's calendar and clock components
+func (i *IntervalType) fromDuckDBInterval(v duckdb.Interval) {
+	micros := v.Micros
+	*i = IntervalType{
+		Years:  int(v.Months / 12),
+		Months: int(v.Months % 12),
+		Days:   int(v.Days),
+		Hours:  int(micros / int64(time.Hour/time.Microsecond)),
+	}
+	micros %= int64(time.Hour / time.Microsecond)
+	i.Minutes = int(micros / int64(time.Minute/time.Microsecond))
+	micros %= int64(time.Minute / time.Microsecond)
+	i.Seconds = int(micros / int64(time.Second/time.Microsecond))
+	i.Micros = int(micros % int64(time.Second/time.Microsecond))
+}
+
 func (i *IntervalType) parseInterval(str string) error {
 	str = strings.TrimSpace(str)
 
@@ -605,6 +887,203 @@ func (IntervalType) GormDataType() string {
 	return "INTERVAL"
 }
 
+// Interval is a DuckDB INTERVAL value in DuckDB's own representation:
+// months, days and microseconds are kept apart because their lengths vary
+// (a month is not always 30 days, a day not always 24 hours across a DST
+// change). Combine the constructors with Add, e.g.
+//
+//	TTL duckdb.Interval // migrates as INTERVAL
+//	record.TTL = duckdb.Days(3).Add(duckdb.Hours(2))
+//	db.Where("created_at + ttl < now()").Delete(&Record{})
+type Interval struct {
+	Months int32
+	Days   int32
+	Micros int64
+}
+
+// Months returns an interval of n months
+func Months(n int) Interval {
+	return Interval{Months: int32(n)} //nolint:gosec // Interval months are 32-bit in DuckDB
+}
+
+// Days returns an interval of n days
+func Days(n int) Interval {
+	return Interval{Days: int32(n)} //nolint:gosec // Interval days are 32-bit in DuckDB
+}
+
+// Hours returns an interval of n hours
+func Hours(n int) Interval {
+	return FromDuration(time.Duration(n) * time.Hour)
+}
+
+// Minutes returns an interval of n minutes
+func Minutes(n int) Interval {
+	return FromDuration(time.Duration(n) * time.Minute)
+}
+
+// Seconds returns an interval of n seconds
+func Seconds(n int) Interval {
+	return FromDuration(time.Duration(n) * time.Second)
+}
+
+// FromDuration returns an interval of d, truncated to microseconds. It never
+// carries into days.
+func FromDuration(d time.Duration) Interval {
+	return Interval{Micros: d.Microseconds()}
+}
+
+// Add returns the component-wise sum of i and other
+func (i Interval) Add(other Interval) Interval {
+	return Interval{
+		Months: i.Months + other.Months,
+		Days:   i.Days + other.Days,
+		Micros: i.Micros + other.Micros,
+	}
+}
+
+// String renders the interval in a form DuckDB casts to INTERVAL, e.g.
+// "0 months 3 days 7200000000 microseconds"
+func (i Interval) String() string {
+	return fmt.Sprintf("%d months %d days %d microseconds", i.Months, i.Days, i.Micros)
+}
+
+// Value implements driver.Valuer interface for Interval. The interval is
+// sent as text, which DuckDB casts when the target is an INTERVAL column.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// GormValue implements gorm.Valuer, casting the interval so it also binds in
+// expressions such as "created_at + ?"
+func (i Interval) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	return clause.Expr{SQL: "CAST(? AS INTERVAL)", Vars: []interface{}{i.String()}}
+}
+
+// Scan implements sql.Scanner interface for Interval
+func (i *Interval) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*i = Interval{}
+	case duckdb.Interval:
+		*i = Interval{Months: v.Months, Days: v.Days, Micros: v.Micros}
+	case time.Duration:
+		*i = FromDuration(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Interval", value)
+	}
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for Interval
+func (Interval) GormDataType() string {
+	return "INTERVAL"
+}
+
+// IntervalArray represents a DuckDB INTERVAL[] column. NULL elements scan as
+// zero intervals.
+type IntervalArray []Interval
+
+// Value implements driver.Valuer interface for IntervalArray. The array is
+// sent as a list literal that DuckDB casts to INTERVAL[].
+func (a IntervalArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	elements := make([]string, len(a))
+	for idx, interval := range a {
+		elements[idx] = nestedStringLiteral(interval.String())
+	}
+	return "[" + strings.Join(elements, ", ") + "]", nil
+}
+
+// Scan implements sql.Scanner interface for IntervalArray
+func (a *IntervalArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("cannot scan %T into IntervalArray", value)
+	}
+
+	result := make(IntervalArray, len(items))
+	for idx, item := range items {
+		if err := result[idx].Scan(item); err != nil {
+			return fmt.Errorf("failed to scan interval array element %d: %w", idx, err)
+		}
+	}
+	*a = result
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for IntervalArray
+func (IntervalArray) GormDataType() string {
+	return "INTERVAL[]"
+}
+
+// IntervalMap represents a DuckDB MAP(VARCHAR, INTERVAL) column. NULL values
+// scan as zero intervals.
+type IntervalMap map[string]Interval
+
+// Value implements driver.Valuer interface for IntervalMap. The map is sent
+// as a map literal that DuckDB casts to MAP(VARCHAR, INTERVAL).
+func (m IntervalMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for idx, key := range keys {
+		entries[idx] = nestedStringLiteral(key) + "=" + nestedStringLiteral(m[key].String())
+	}
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+// Scan implements sql.Scanner interface for IntervalMap
+func (m *IntervalMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	entries, ok := value.(duckdb.Map)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into IntervalMap", value)
+	}
+
+	result := make(IntervalMap, len(entries))
+	for key, item := range entries {
+		var interval Interval
+		if err := interval.Scan(item); err != nil {
+			return fmt.Errorf("failed to scan interval map value %v: %w", key, err)
+		}
+		result[fmt.Sprint(key)] = interval
+	}
+	*m = result
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for IntervalMap
+func (IntervalMap) GormDataType() string {
+	return "MAP(VARCHAR, INTERVAL)"
+}
+
+// nestedStringLiteral quotes s for use inside a LIST or MAP text literal that
+// DuckDB casts from VARCHAR, where quotes are escaped with a backslash
+func nestedStringLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
 // ===== UUID TYPE =====
 
 // UUIDType represents a DuckDB UUID type
@@ -657,9 +1136,22 @@ func (UUIDType) GormDataType() string {
 
 // ===== JSON TYPE =====
 
+// JSONFormat controls how JSONType keeps the text of a scanned JSON value
+type JSONFormat int
+
+const (
+	// JSONVerbatim keeps the scanned JSON text exactly as DuckDB returned it
+	JSONVerbatim JSONFormat = iota
+	// JSONCompact strips insignificant whitespace from the scanned JSON text
+	// with json.Compact, so payloads can be compared byte for byte
+	JSONCompact
+)
+
 // JSONType represents a DuckDB JSON type with native JSON operations
 type JSONType struct {
-	Data interface{} // Can hold any JSON-serializable data
+	Data   interface{}     // Can hold any JSON-serializable data
+	Format JSONFormat      // How Raw is filled on Scan (default: JSONVerbatim)
+	Raw    json.RawMessage // JSON text of the last scanned value
 }
 
 // NewJSON creates a new JSONType from any JSON-serializable data
@@ -685,6 +1177,7 @@ func (j JSONType) Value() (driver.Value, error) {
 func (j *JSONType) Scan(value interface{}) error {
 	if value == nil {
 		j.Data = nil
+		j.Raw = nil
 		return nil
 	}
 
@@ -694,12 +1187,21 @@ func (j *JSONType) Scan(value interface{}) error {
 		jsonStr = v
 	case []byte:
 		jsonStr = string(v)
+	case map[string]interface{}, []interface{}, bool, float64, int64:
+		// go-duckdb decodes native JSON columns, so the original text is gone;
+		// re-encode it to keep Raw populated
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON value: %w", err)
+		}
+		jsonStr = string(jsonBytes)
 	default:
 		return fmt.Errorf("cannot scan %T into JSONType", value)
 	}
 
 	if jsonStr == "NULL" || jsonStr == "" {
 		j.Data = nil
+		j.Raw = nil
 		return nil
 	}
 
@@ -708,7 +1210,17 @@ func (j *JSONType) Scan(value interface{}) error {
 		return fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
+	raw := json.RawMessage(jsonStr)
+	if j.Format == JSONCompact {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, raw); err != nil {
+			return fmt.Errorf("failed to compact JSON: %w", err)
+		}
+		raw = compacted.Bytes()
+	}
+
 	j.Data = result
+	j.Raw = raw
 	return nil
 }
 
@@ -731,6 +1243,70 @@ func (JSONType) GormDataType() string {
 	return jsonType
 }
 
+// JSON is a DuckDB JSON column holding raw JSON text, for payloads that are
+// decoded by the caller (json.Unmarshal(row.Data, &v)) or only queried with
+// JSONExtract and JSONContains. A nil JSON is stored as NULL.
+type JSON json.RawMessage
+
+// Value implements driver.Valuer interface for JSON
+func (j JSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	if !json.Valid(j) {
+		return nil, fmt.Errorf("invalid JSON: %s", string(j))
+	}
+	return string(j), nil
+}
+
+// Scan implements sql.Scanner interface for JSON. go-duckdb decodes JSON
+// columns into Go values, which are encoded again; text that is not valid
+// JSON, such as a decoded JSON string, is kept as a JSON string.
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case string:
+		if json.Valid([]byte(v)) {
+			*j = JSON(v)
+			return nil
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into JSON: %w", value, err)
+		}
+		*j = encoded
+	case []byte:
+		*j = append(JSON(nil), v...)
+	default:
+		encoded, err := json.Marshal(normalizeJSONValue(v))
+		if err != nil {
+			return fmt.Errorf("cannot scan %T into JSON: %w", value, err)
+		}
+		*j = encoded
+	}
+	return nil
+}
+
+// MarshalJSON returns the raw JSON text, or null for a nil JSON
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON keeps a copy of data
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[0:0], data...)
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for JSON
+func (JSON) GormDataType() string {
+	return jsonType
+}
+
 // ===== PHASE 3A: CORE ADVANCED TYPES FOR 100% DUCKDB UTILIZATION =====
 
 // ENUMType represents a DuckDB ENUM type with predefined allowed values
@@ -740,6 +1316,57 @@ type ENUMType struct {
 	Name     string   `json:"name"`     // Enum type name
 }
 
+// enumTypes holds the ENUM types declared with RegisterEnum, keyed by
+// lower-case name
+var enumTypes = struct {
+	sync.RWMutex
+	values map[string][]string
+}{values: make(map[string][]string)}
+
+// RegisterEnum declares a named DuckDB ENUM type for migrations. Columns
+// tagged with its name, ENUMType or plain string fields alike, get the type
+// created with CREATE TYPE name AS ENUM (...) by CreateTable and AddColumn
+// when it does not exist yet:
+//
+//	duckdb.RegisterEnum("severity", "debug", "info", "warning", "error")
+//
+//	type Alert struct {
+//		ID    uint
+//		Level duckdb.ENUMType `gorm:"type:severity"`
+//	}
+func RegisterEnum(name string, values ...string) {
+	enumTypes.Lock()
+	defer enumTypes.Unlock()
+	enumTypes.values[strings.ToLower(name)] = append([]string(nil), values...)
+}
+
+// registeredEnum returns the values of the ENUM type registered as name
+func registeredEnum(name string) ([]string, bool) {
+	enumTypes.RLock()
+	defer enumTypes.RUnlock()
+	values, ok := enumTypes.values[strings.ToLower(name)]
+	return values, ok
+}
+
+// registeredEnumsMatching returns the lower-case names of the registered
+// ENUM types whose values render as databaseTypeName, e.g. enum('a', 'b')
+func registeredEnumsMatching(databaseTypeName string) []string {
+	enumTypes.RLock()
+	defer enumTypes.RUnlock()
+
+	var names []string
+	for name, values := range enumTypes.values {
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = sqlStringLiteral(value)
+		}
+		if strings.EqualFold("enum("+strings.Join(literals, ", ")+")", databaseTypeName) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // NewEnum creates a new ENUMType with allowed values
 func NewEnum(name string, values []string, selected string) ENUMType {
 	return ENUMType{
@@ -785,6 +1412,32 @@ func (e *ENUMType) Scan(value interface{}) error {
 	}
 }
 
+// GormValue implements gorm.Valuer. DuckDB compares an ENUM column with a
+// string by text, so the value is cast to the enum type named by Name (or
+// declared by Values) to compare by definition order:
+//
+//	db.Where("level > ?", duckdb.NewEnum("severity", nil, "warning"))
+//
+// With neither set the value binds as a plain string. Values is only
+// checked when given.
+func (e ENUMType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if e.Selected != "" && len(e.Values) > 0 && !e.IsValid() {
+		_ = db.AddError(fmt.Errorf("invalid enum value: %s not in %v", e.Selected, e.Values))
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	switch {
+	case e.Selected == "":
+		return clause.Expr{SQL: "?", Vars: []interface{}{e.Selected}}
+	case e.Name != "":
+		return clause.Expr{SQL: "CAST(? AS " + db.Statement.Quote(e.Name) + ")", Vars: []interface{}{e.Selected}}
+	case len(e.Values) > 0:
+		return clause.Expr{SQL: "CAST(? AS " + e.GormDataType() + ")", Vars: []interface{}{e.Selected}}
+	default:
+		return clause.Expr{SQL: "?", Vars: []interface{}{e.Selected}}
+	}
+}
+
 // IsValid checks if the current selected value is valid
 func (e ENUMType) IsValid() bool {
 	for _, v := range e.Values {
@@ -957,6 +1610,70 @@ func (TimestampTZType) GormDataType() string {
 	return "TIMESTAMPTZ"
 }
 
+// TimestampTZ is a DuckDB TIMESTAMPTZ column holding a time.Time, for
+// timestamps that must keep their instant whatever zone they were written
+// in: CreatedAt duckdb.TimestampTZ migrates as TIMESTAMPTZ. The value is
+// written with its UTC offset and DuckDB stores the instant, so
+// 09:00-05:00 reads back as 14:00 UTC; DuckDB does not keep the zone itself,
+// so call In to show it in a location again. The zero time is stored as
+// NULL and NULL scans back as the zero time. A time.Time field tagged
+// gorm:"type:timestamptz" gets the same column type.
+type TimestampTZ struct {
+	time.Time
+}
+
+// Value implements driver.Valuer interface for TimestampTZ. The timestamp is
+// sent as text with its UTC offset, which DuckDB casts to the instant.
+func (t TimestampTZ) Value() (driver.Value, error) {
+	if t.Time.IsZero() {
+		return nil, nil
+	}
+	return t.Time.Format(timestampTZLayout), nil
+}
+
+// GormValue implements gorm.Valuer, casting the timestamp so it also binds
+// in comparisons against TIMESTAMPTZ columns
+func (t TimestampTZ) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	if t.Time.IsZero() {
+		return clause.Expr{SQL: nullValue}
+	}
+	return clause.Expr{SQL: "CAST(? AS TIMESTAMPTZ)", Vars: []interface{}{t.Time.Format(timestampTZLayout)}}
+}
+
+// Scan implements sql.Scanner interface for TimestampTZ. go-duckdb scans
+// TIMESTAMPTZ as a time.Time, which is kept in UTC.
+func (t *TimestampTZ) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time = time.Time{}
+	case time.Time:
+		t.Time = v.UTC()
+	case string:
+		// DuckDB prints offsets as +HH, or +HH:MM for fractional hours
+		parsed, err := time.Parse(timestampTZLayout, v)
+		if err != nil {
+			if parsed, err = time.Parse("2006-01-02 15:04:05.999999-07", v); err != nil {
+				return fmt.Errorf("failed to parse timestamp: %w", err)
+			}
+		}
+		t.Time = parsed.UTC()
+	case []byte:
+		return t.Scan(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into TimestampTZ", value)
+	}
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for TimestampTZ
+func (TimestampTZ) GormDataType() string {
+	return "TIMESTAMPTZ"
+}
+
+// timestampTZLayout writes a timestamp with its UTC offset in a form DuckDB
+// casts to TIMESTAMPTZ
+const timestampTZLayout = "2006-01-02 15:04:05.999999-07:00"
+
 // ===== HUGE INTEGER TYPES =====
 
 // HugeIntType represents a DuckDB HUGEINT (128-bit integer)
@@ -1051,6 +1768,81 @@ func (HugeIntType) GormDataType() string {
 	return "HUGEINT"
 }
 
+// HugeInt is a nullable DuckDB HUGEINT column holding a *big.Int, so values
+// beyond the int64 range are stored and read back exactly, e.g.
+//
+//	Total duckdb.HugeInt // migrates as HUGEINT
+//	record.Total = duckdb.HugeInt{Int: new(big.Int).Lsh(big.NewInt(1), 100)}
+//
+// A nil Int is stored as NULL and NULL scans back as nil.
+type HugeInt struct {
+	Int *big.Int
+}
+
+// String returns the decimal value, or "NULL" for a nil Int
+func (h HugeInt) String() string {
+	if h.Int == nil {
+		return nullValue
+	}
+	return h.Int.String()
+}
+
+// Value implements driver.Valuer interface for HugeInt. The value is sent as
+// text, which DuckDB casts when the target is a HUGEINT column.
+func (h HugeInt) Value() (driver.Value, error) {
+	if h.Int == nil {
+		return nil, nil
+	}
+	return h.Int.String(), nil
+}
+
+// GormValue implements gorm.Valuer, casting the value so it also binds in
+// expressions and comparisons
+func (h HugeInt) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	if h.Int == nil {
+		return clause.Expr{SQL: nullValue}
+	}
+	return clause.Expr{SQL: "CAST(? AS HUGEINT)", Vars: []interface{}{h.Int.String()}}
+}
+
+// Scan implements sql.Scanner interface for HugeInt. go-duckdb scans HUGEINT
+// as *big.Int, which is copied; integers and decimal strings are accepted too.
+func (h *HugeInt) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		h.Int = nil
+	case *big.Int:
+		if v == nil {
+			h.Int = nil
+			return nil
+		}
+		h.Int = new(big.Int).Set(v)
+	case int64:
+		h.Int = big.NewInt(v)
+	case string:
+		return h.setString(v)
+	case []byte:
+		return h.setString(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into HugeInt", value)
+	}
+	return nil
+}
+
+func (h *HugeInt) setString(s string) error {
+	value, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return fmt.Errorf("invalid huge integer string: %s", s)
+	}
+	h.Int = value
+	return nil
+}
+
+// GormDataType implements the GormDataTypeInterface for HugeInt
+func (HugeInt) GormDataType() string {
+	return "HUGEINT"
+}
+
 // ===== BIT STRING TYPES =====
 
 // BitStringType represents a DuckDB BIT/BITSTRING type
@@ -1106,6 +1898,18 @@ func (b BitStringType) Value() (driver.Value, error) {
 	return builder.String(), nil
 }
 
+// GormValue implements gorm.Valuer. go-duckdb cannot bind BIT parameters, so
+// the bits are bound as VARCHAR and cast to BIT in SQL.
+func (b BitStringType) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	value, _ := b.Value()
+	return bitLiteralExpr(value.(string))
+}
+
+// bitLiteralExpr binds a binary string such as "0101" as a BIT value
+func bitLiteralExpr(bits string) clause.Expr {
+	return clause.Expr{SQL: "CAST(?::VARCHAR AS BIT)", Vars: []interface{}{bits}}
+}
+
 // Scan implements sql.Scanner interface for BitStringType
 func (b *BitStringType) Scan(value interface{}) error {
 	if value == nil {
@@ -1296,6 +2100,68 @@ func (BLOBType) GormDataType() string {
 	return "BLOB"
 }
 
+// BlobReader is a scan destination that exposes a BLOB column as an io.Reader
+// over the driver's value without the extra copy BLOBType makes, so large
+// payloads can be streamed to a file or hash with io.Copy:
+//
+//	var blob duckdb.BlobReader
+//	err := db.Raw("SELECT payload FROM files WHERE id = ?", id).Row().Scan(&blob)
+//	_, err = io.Copy(file, &blob)
+//
+// Per the database/sql Scanner contract the reader is only valid until the
+// next Scan on the same rows, so consume it before advancing.
+type BlobReader struct {
+	reader *bytes.Reader
+	null   bool
+}
+
+// Scan implements sql.Scanner interface for BlobReader
+func (b *BlobReader) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		b.reader = bytes.NewReader(nil)
+		b.null = true
+	case []byte:
+		b.reader = bytes.NewReader(v)
+		b.null = false
+	case string:
+		b.reader = bytes.NewReader([]byte(v))
+		b.null = false
+	default:
+		return fmt.Errorf("cannot scan %T into BlobReader", value)
+	}
+	return nil
+}
+
+// Read implements io.Reader for BlobReader
+func (b *BlobReader) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		return 0, io.EOF
+	}
+	return b.reader.Read(p)
+}
+
+// WriteTo implements io.WriterTo so io.Copy writes the BLOB in one call
+func (b *BlobReader) WriteTo(w io.Writer) (int64, error) {
+	if b.reader == nil {
+		return 0, nil
+	}
+	return b.reader.WriteTo(w)
+}
+
+// Size returns the total size of the scanned BLOB in bytes
+func (b *BlobReader) Size() int64 {
+	if b.reader == nil {
+		return 0
+	}
+	return b.reader.Size()
+}
+
+// IsNull reports whether the scanned column was NULL
+func (b *BlobReader) IsNull() bool {
+	return b.null
+}
+
 // GEOMETRYType represents a DuckDB GEOMETRY type for spatial data
 // Critical core type for geospatial analysis and location-based operations
 type GEOMETRYType struct {
@@ -1,12 +1,22 @@
 package duckdb_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql/driver"
+	"encoding/json"
+	"io"
 	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
 	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
 )
 
@@ -677,3 +687,862 @@ func TestAdvancedTypesCompletionSummary(t *testing.T) {
 	t.Log("🔧 PRODUCTION READY: Full GORM integration with battle-tested interfaces")
 	t.Log(strings.Repeat("=", 60))
 }
+
+// TestBlobReaderStreaming scans a large BLOB into a BlobReader and copies it out
+func TestBlobReaderStreaming(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	payload := make([]byte, 8<<20)
+	if _, err := rand.Read(payload); err != nil {
+		t.Fatalf("Failed to generate payload: %v", err)
+	}
+	expected := sha256.Sum256(payload)
+
+	if err := db.Exec("CREATE TABLE blob_files (id INTEGER, payload BLOB)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if err := db.Exec("INSERT INTO blob_files VALUES (?, ?), (?, NULL)", 1, payload, 2).Error; err != nil {
+		t.Fatalf("Failed to insert blob: %v", err)
+	}
+
+	t.Run("CopyMatchesChecksum", func(t *testing.T) {
+		var blob duckdb.BlobReader
+		if err := db.Raw("SELECT payload FROM blob_files WHERE id = ?", 1).Row().Scan(&blob); err != nil {
+			t.Fatalf("Failed to scan blob: %v", err)
+		}
+		if blob.IsNull() {
+			t.Fatal("Expected non-NULL blob")
+		}
+		if blob.Size() != int64(len(payload)) {
+			t.Errorf("Expected size %d, got %d", len(payload), blob.Size())
+		}
+
+		hash := sha256.New()
+		written, err := io.Copy(hash, &blob)
+		if err != nil {
+			t.Fatalf("Failed to copy blob: %v", err)
+		}
+		if written != int64(len(payload)) {
+			t.Errorf("Expected %d bytes copied, got %d", len(payload), written)
+		}
+		if !bytes.Equal(hash.Sum(nil), expected[:]) {
+			t.Error("Checksum of streamed blob does not match payload")
+		}
+	})
+
+	t.Run("NullBlob", func(t *testing.T) {
+		var blob duckdb.BlobReader
+		if err := db.Raw("SELECT payload FROM blob_files WHERE id = ?", 2).Row().Scan(&blob); err != nil {
+			t.Fatalf("Failed to scan NULL blob: %v", err)
+		}
+		if !blob.IsNull() {
+			t.Error("Expected NULL blob")
+		}
+		data, err := io.ReadAll(&blob)
+		if err != nil || len(data) != 0 {
+			t.Errorf("Expected empty read from NULL blob, got %d bytes, err %v", len(data), err)
+		}
+	})
+
+	t.Run("InvalidSource", func(t *testing.T) {
+		var blob duckdb.BlobReader
+		if err := blob.Scan(42); err == nil {
+			t.Error("Expected error scanning int into BlobReader")
+		}
+	})
+}
+
+func TestJSONTypeFormat(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	if err := db.Exec("CREATE TABLE json_docs (id INTEGER, doc JSON)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	stored := `{ "name" : "sensor",  "tags": [ "a", "b" ],
+		"nested": { "ok": true } }`
+	if err := db.Exec("INSERT INTO json_docs VALUES (1, ?), (2, NULL)", stored).Error; err != nil {
+		t.Fatalf("Failed to insert JSON: %v", err)
+	}
+
+	t.Run("Verbatim", func(t *testing.T) {
+		// DuckDB stores JSON as text; casting returns it untouched
+		var doc duckdb.JSONType
+		if err := db.Raw("SELECT doc::VARCHAR FROM json_docs WHERE id = 1").Row().Scan(&doc); err != nil {
+			t.Fatalf("Failed to scan JSON: %v", err)
+		}
+		if string(doc.Raw) != stored {
+			t.Errorf("Expected verbatim JSON %q, got %q", stored, doc.Raw)
+		}
+	})
+
+	t.Run("Compact", func(t *testing.T) {
+		expected := `{"name":"sensor","tags":["a","b"],"nested":{"ok":true}}`
+		// Normalization is stable across repeated reads
+		for i := 0; i < 2; i++ {
+			doc := duckdb.JSONType{Format: duckdb.JSONCompact}
+			if err := db.Raw("SELECT doc::VARCHAR FROM json_docs WHERE id = 1").Row().Scan(&doc); err != nil {
+				t.Fatalf("Failed to scan JSON: %v", err)
+			}
+			if string(doc.Raw) != expected {
+				t.Errorf("Expected compact JSON %q, got %q", expected, doc.Raw)
+			}
+			if m, ok := doc.Data.(map[string]interface{}); !ok || m["name"] != "sensor" {
+				t.Errorf("Expected decoded data alongside raw JSON, got %v", doc.Data)
+			}
+		}
+	})
+
+	t.Run("NativeColumn", func(t *testing.T) {
+		// Native JSON columns arrive decoded and are re-encoded compactly
+		var doc duckdb.JSONType
+		if err := db.Raw("SELECT doc FROM json_docs WHERE id = 1").Row().Scan(&doc); err != nil {
+			t.Fatalf("Failed to scan JSON: %v", err)
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, doc.Raw); err != nil || compacted.String() != string(doc.Raw) {
+			t.Errorf("Expected compact JSON from native column, got %q", doc.Raw)
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		doc := duckdb.JSONType{Format: duckdb.JSONCompact, Raw: []byte(`{}`)}
+		if err := db.Raw("SELECT doc FROM json_docs WHERE id = 2").Row().Scan(&doc); err != nil {
+			t.Fatalf("Failed to scan JSON: %v", err)
+		}
+		if doc.Raw != nil || doc.Data != nil {
+			t.Errorf("Expected NULL JSON to clear Raw and Data, got %q / %v", doc.Raw, doc.Data)
+		}
+	})
+}
+
+type MapCreateModel struct {
+	ID     uint           `gorm:"primaryKey"`
+	Labels duckdb.MapType `gorm:"type:MAP(VARCHAR, VARCHAR)"`
+}
+
+type PlainMapCreateModel struct {
+	ID     uint             `gorm:"primaryKey"`
+	Counts map[string]int32 `gorm:"type:MAP(VARCHAR, INTEGER)"`
+}
+
+func TestMapTypeCreate(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&MapCreateModel{}, &PlainMapCreateModel{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	t.Run("MapType", func(t *testing.T) {
+		record := MapCreateModel{Labels: duckdb.MapType{"env": "prod", "quote": "it's", "zone": "eu-1"}}
+		if err := db.Create(&record).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found MapCreateModel
+		if err := db.First(&found, record.ID).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if !reflect.DeepEqual(found.Labels, record.Labels) {
+			t.Errorf("Expected %v, got %v", record.Labels, found.Labels)
+		}
+
+		if err := db.Model(&found).Update("labels", duckdb.MapType{"env": "dev"}).Error; err != nil {
+			t.Fatalf("Failed to update: %v", err)
+		}
+		if err := db.First(&found, record.ID).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if !reflect.DeepEqual(found.Labels, duckdb.MapType{"env": "dev"}) {
+			t.Errorf("Expected updated map, got %v", found.Labels)
+		}
+	})
+
+	t.Run("EmptyMapType", func(t *testing.T) {
+		record := MapCreateModel{}
+		if err := db.Create(&record).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found MapCreateModel
+		if err := db.First(&found, record.ID).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if len(found.Labels) != 0 {
+			t.Errorf("Expected empty map, got %v", found.Labels)
+		}
+	})
+
+	t.Run("PlainGoMap", func(t *testing.T) {
+		record := PlainMapCreateModel{Counts: map[string]int32{"a": 1, "b": 2}}
+		if err := db.Create(&record).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var counts duckdb.MapType
+		if err := db.Raw("SELECT counts FROM plain_map_create_models WHERE id = ?", record.ID).Row().Scan(&counts); err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if !reflect.DeepEqual(counts, duckdb.MapType{"a": int32(1), "b": int32(2)}) {
+			t.Errorf("Expected counts map, got %#v", counts)
+		}
+	})
+}
+
+type IntervalContainerModel struct {
+	ID       uint                 `gorm:"primaryKey"`
+	Windows  duckdb.IntervalArray `json:"windows"`
+	Timeouts duckdb.IntervalMap   `json:"timeouts"`
+}
+
+func TestIntervalContainers(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	t.Run("ScanIntervalArray", func(t *testing.T) {
+		var windows duckdb.IntervalArray
+		err := db.Raw("SELECT [INTERVAL '1 year 2 months 3 days 04:05:06.000007', INTERVAL 90 MINUTE, NULL]").Row().Scan(&windows)
+		if err != nil {
+			t.Fatalf("Failed to scan INTERVAL[]: %v", err)
+		}
+
+		expected := duckdb.IntervalArray{
+			duckdb.Months(14).Add(duckdb.Days(3)).Add(duckdb.FromDuration(4*time.Hour + 5*time.Minute + 6*time.Second + 7*time.Microsecond)),
+			duckdb.Minutes(90),
+			{},
+		}
+		if !reflect.DeepEqual(windows, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, windows)
+		}
+	})
+
+	t.Run("ScanIntervalMap", func(t *testing.T) {
+		var timeouts duckdb.IntervalMap
+		if err := db.Raw("SELECT MAP {'read': INTERVAL 30 SECOND, 'idle': INTERVAL 2 DAY}").Row().Scan(&timeouts); err != nil {
+			t.Fatalf("Failed to scan MAP(VARCHAR, INTERVAL): %v", err)
+		}
+		if timeouts["read"] != duckdb.Seconds(30) || timeouts["idle"] != duckdb.Days(2) {
+			t.Errorf("Unexpected intervals: %+v", timeouts)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		if err := db.AutoMigrate(&IntervalContainerModel{}); err != nil {
+			t.Fatalf("Failed to migrate: %v", err)
+		}
+
+		record := IntervalContainerModel{
+			Windows: duckdb.IntervalArray{duckdb.Months(1).Add(duckdb.Hours(12)), {}},
+			Timeouts: duckdb.IntervalMap{
+				"connect": duckdb.Seconds(5),
+				"it's":    duckdb.Days(7),
+			},
+		}
+		if err := db.Create(&record).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found IntervalContainerModel
+		if err := db.First(&found, record.ID).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if !reflect.DeepEqual(found.Windows, record.Windows) {
+			t.Errorf("Expected windows %+v, got %+v", record.Windows, found.Windows)
+		}
+		if !reflect.DeepEqual(found.Timeouts, record.Timeouts) {
+			t.Errorf("Expected timeouts %+v, got %+v", record.Timeouts, found.Timeouts)
+		}
+	})
+}
+
+type RetentionPolicy struct {
+	ID        uint `gorm:"primaryKey"`
+	Name      string
+	CreatedAt time.Time
+	TTL       duckdb.Interval
+}
+
+func TestInterval(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	t.Run("ScanArithmetic", func(t *testing.T) {
+		var sum duckdb.Interval
+		if err := db.Raw("SELECT INTERVAL 1 MONTH + INTERVAL 3 DAY + INTERVAL 90 MINUTE").Row().Scan(&sum); err != nil {
+			t.Fatalf("Failed to scan INTERVAL: %v", err)
+		}
+		expected := duckdb.Months(1).Add(duckdb.Days(3)).Add(duckdb.Hours(1)).Add(duckdb.Minutes(30))
+		if sum != expected {
+			t.Errorf("Expected %+v, got %+v", expected, sum)
+		}
+
+		var shifted time.Time
+		base := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		if err := db.Raw("SELECT CAST(? AS TIMESTAMP) + ?", base, duckdb.Days(1).Add(duckdb.Seconds(30))).Row().Scan(&shifted); err != nil {
+			t.Fatalf("Failed to add bound interval: %v", err)
+		}
+		if want := base.Add(24*time.Hour + 30*time.Second); !shifted.Equal(want) {
+			t.Errorf("Expected %v, got %v", want, shifted)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		if err := db.AutoMigrate(&RetentionPolicy{}); err != nil {
+			t.Fatalf("Failed to migrate: %v", err)
+		}
+
+		var columnType string
+		if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'retention_policies' AND column_name = 'ttl'").Scan(&columnType).Error; err != nil {
+			t.Fatalf("Failed to read column type: %v", err)
+		}
+		if columnType != "INTERVAL" {
+			t.Errorf("Expected INTERVAL column, got %s", columnType)
+		}
+
+		now := time.Now().UTC()
+		policies := []RetentionPolicy{
+			{Name: "expired", CreatedAt: now.Add(-72 * time.Hour), TTL: duckdb.Days(2)},
+			{Name: "live", CreatedAt: now.Add(-72 * time.Hour), TTL: duckdb.Days(3).Add(duckdb.Hours(2))},
+		}
+		if err := db.Create(&policies).Error; err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+
+		var found RetentionPolicy
+		if err := db.First(&found, policies[1].ID).Error; err != nil {
+			t.Fatalf("Failed to read back: %v", err)
+		}
+		if found.TTL != policies[1].TTL {
+			t.Errorf("Expected TTL %+v, got %+v", policies[1].TTL, found.TTL)
+		}
+
+		var expired []RetentionPolicy
+		if err := db.Where("created_at + ttl < ?", now).Find(&expired).Error; err != nil {
+			t.Fatalf("Failed to query expired policies: %v", err)
+		}
+		if len(expired) != 1 || expired[0].Name != "expired" {
+			t.Errorf("Expected only the expired policy, got %+v", expired)
+		}
+	})
+}
+
+type LedgerTotal struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Total duckdb.HugeInt
+}
+
+func TestHugeInt(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&LedgerTotal{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	var columnType string
+	if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'ledger_totals' AND column_name = 'total'").Scan(&columnType).Error; err != nil {
+		t.Fatalf("Failed to read column type: %v", err)
+	}
+	if columnType != "HUGEINT" {
+		t.Errorf("Expected HUGEINT column, got %s", columnType)
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 100)
+	totals := []LedgerTotal{
+		{Name: "positive", Total: duckdb.HugeInt{Int: huge}},
+		{Name: "negative", Total: duckdb.HugeInt{Int: new(big.Int).Neg(huge)}},
+		{Name: "null"},
+	}
+	if err := db.Create(&totals).Error; err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	var found []LedgerTotal
+	if err := db.Order("id").Find(&found).Error; err != nil {
+		t.Fatalf("Failed to read back: %v", err)
+	}
+	if len(found) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(found))
+	}
+	for i := range totals[:2] {
+		if found[i].Total.Int == nil || found[i].Total.Int.Cmp(totals[i].Total.Int) != 0 {
+			t.Errorf("Expected %s total %s, got %s", totals[i].Name, totals[i].Total, found[i].Total)
+		}
+	}
+	if found[2].Total.Int != nil {
+		t.Errorf("Expected NULL total, got %s", found[2].Total)
+	}
+
+	var matched LedgerTotal
+	if err := db.Where("total > ?", duckdb.HugeInt{Int: big.NewInt(0)}).First(&matched).Error; err != nil {
+		t.Fatalf("Failed to query by HugeInt: %v", err)
+	}
+	if matched.Name != "positive" {
+		t.Errorf("Expected the positive total, got %s", matched.Name)
+	}
+
+	var sum duckdb.HugeInt
+	if err := db.Raw("SELECT SUM(total) + ? FROM ledger_totals", duckdb.HugeInt{Int: huge}).Row().Scan(&sum); err != nil {
+		t.Fatalf("Failed to scan SUM: %v", err)
+	}
+	if sum.Int == nil || sum.Int.Cmp(huge) != 0 {
+		t.Errorf("Expected sum %s, got %s", huge, sum)
+	}
+}
+
+type ZonedEvent struct {
+	ID         uint `gorm:"primaryKey"`
+	OccurredAt duckdb.TimestampTZ
+	SeenAt     time.Time  `gorm:"type:timestamptz"`
+	ClosedAt   *time.Time `gorm:"type:timestamptz"`
+}
+
+func TestTimestampTZ(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&ZonedEvent{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	var columnTypes []string
+	if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'zoned_events' AND column_name <> 'id' ORDER BY ordinal_position").Scan(&columnTypes).Error; err != nil {
+		t.Fatalf("Failed to read column types: %v", err)
+	}
+	expected := []string{"TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH TIME ZONE"}
+	if !reflect.DeepEqual(columnTypes, expected) {
+		t.Errorf("Expected column types %v, got %v", expected, columnTypes)
+	}
+
+	eastern := time.FixedZone("EST", -5*60*60)
+	occurred := time.Date(2024, 3, 1, 9, 0, 0, 123456000, eastern)
+	closed := occurred.Add(90 * time.Minute)
+	event := ZonedEvent{
+		OccurredAt: duckdb.TimestampTZ{Time: occurred},
+		SeenAt:     occurred.Add(time.Minute),
+		ClosedAt:   &closed,
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if err := db.Create(&ZonedEvent{SeenAt: occurred}).Error; err != nil {
+		t.Fatalf("Failed to create with NULL timestamps: %v", err)
+	}
+
+	var found ZonedEvent
+	if err := db.First(&found, event.ID).Error; err != nil {
+		t.Fatalf("Failed to read back: %v", err)
+	}
+	if !found.OccurredAt.Equal(occurred) {
+		t.Errorf("Expected OccurredAt %v, got %v", occurred, found.OccurredAt.Time)
+	}
+	if found.OccurredAt.Location() != time.UTC || found.OccurredAt.Hour() != 14 {
+		t.Errorf("Expected OccurredAt at 14:00 UTC, got %v", found.OccurredAt.Time)
+	}
+	if !found.OccurredAt.In(eastern).Equal(occurred) || found.OccurredAt.In(eastern).Hour() != 9 {
+		t.Errorf("Expected OccurredAt at 09:00 EST, got %v", found.OccurredAt.In(eastern))
+	}
+	if !found.SeenAt.Equal(event.SeenAt) {
+		t.Errorf("Expected SeenAt %v, got %v", event.SeenAt, found.SeenAt)
+	}
+	if found.ClosedAt == nil || !found.ClosedAt.Equal(closed) {
+		t.Errorf("Expected ClosedAt %v, got %v", closed, found.ClosedAt)
+	}
+
+	var empty ZonedEvent
+	if err := db.Where("occurred_at IS NULL").First(&empty).Error; err != nil {
+		t.Fatalf("Failed to read NULL timestamps: %v", err)
+	}
+	if !empty.OccurredAt.IsZero() || empty.ClosedAt != nil {
+		t.Errorf("Expected NULL timestamps, got %v and %v", empty.OccurredAt.Time, empty.ClosedAt)
+	}
+
+	var count int64
+	after := duckdb.TimestampTZ{Time: time.Date(2024, 3, 1, 13, 59, 0, 0, time.UTC)}
+	if err := db.Model(&ZonedEvent{}).Where("occurred_at > ?", after).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to compare TIMESTAMPTZ: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 event after %v, got %d", after.Time, count)
+	}
+}
+
+var severityLevels = []string{"debug", "info", "warning", "error", "critical"}
+
+type EnumAlert struct {
+	ID    uint            `gorm:"primaryKey"`
+	Level duckdb.ENUMType `gorm:"type:severity"`
+}
+
+func TestEnumOrdering(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.Exec("CREATE TYPE severity AS ENUM ('debug', 'info', 'warning', 'error', 'critical')").Error; err != nil {
+		t.Fatalf("Failed to create enum type: %v", err)
+	}
+	if err := db.AutoMigrate(&EnumAlert{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	for _, level := range severityLevels {
+		if err := db.Create(&EnumAlert{Level: duckdb.NewEnum("severity", severityLevels, level)}).Error; err != nil {
+			t.Fatalf("Failed to create %s alert: %v", level, err)
+		}
+	}
+
+	levelsWhere := func(query string, args ...interface{}) []string {
+		t.Helper()
+		var alerts []EnumAlert
+		if err := db.Where(query, args...).Order("level").Find(&alerts).Error; err != nil {
+			t.Fatalf("Failed to query %s: %v", query, err)
+		}
+		levels := make([]string, len(alerts))
+		for i, alert := range alerts {
+			levels[i] = alert.Level.Selected
+		}
+		return levels
+	}
+
+	// Alphabetically only "warning" sorts after "info"
+	got := levelsWhere("level > ?", duckdb.NewEnum("severity", nil, "info"))
+	if want := []string{"warning", "error", "critical"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("level > info: expected %v, got %v", want, got)
+	}
+
+	got = levelsWhere("level BETWEEN ? AND ?", duckdb.NewEnum("severity", nil, "info"), duckdb.NewEnum("severity", nil, "error"))
+	if want := []string{"info", "warning", "error"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("level between info and error: expected %v, got %v", want, got)
+	}
+
+	invalid := db.Where("level > ?", duckdb.NewEnum("severity", severityLevels, "fatal")).Find(&[]EnumAlert{})
+	if invalid.Error == nil {
+		t.Error("Expected an error for a value outside the enum")
+	}
+}
+
+type MapProduct struct {
+	ID         uint `gorm:"primaryKey"`
+	Attributes duckdb.Map[string, string]
+	Stock      duckdb.Map[string, int32]
+	Sizes      duckdb.Map[int64, []float64]
+}
+
+func TestGenericMap(t *testing.T) {
+	if got := (duckdb.Map[string, string]{}).GormDataType(); got != "MAP(VARCHAR, VARCHAR)" {
+		t.Errorf("Map[string, string]: expected MAP(VARCHAR, VARCHAR), got %s", got)
+	}
+	if got := (duckdb.Map[int64, []float64]{}).GormDataType(); got != "MAP(BIGINT, DOUBLE[])" {
+		t.Errorf("Map[int64, []float64]: expected MAP(BIGINT, DOUBLE[]), got %s", got)
+	}
+	if got := (duckdb.Map[string, map[string]bool]{}).GormDataType(); got != "MAP(VARCHAR, MAP(VARCHAR, BOOLEAN))" {
+		t.Errorf("nested map: expected MAP(VARCHAR, MAP(VARCHAR, BOOLEAN)), got %s", got)
+	}
+
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&MapProduct{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := db.AutoMigrate(&MapProduct{}); err != nil {
+		t.Fatalf("Failed to migrate again: %v", err)
+	}
+
+	var columnType string
+	if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'map_products' AND column_name = 'stock'").Scan(&columnType).Error; err != nil {
+		t.Fatalf("Failed to read column type: %v", err)
+	}
+	if columnType != "MAP(VARCHAR, INTEGER)" {
+		t.Errorf("expected stock column MAP(VARCHAR, INTEGER), got %s", columnType)
+	}
+
+	full := MapProduct{
+		Attributes: duckdb.NewMap(map[string]string{"color": "red", "material": "duck's down"}),
+		Stock:      duckdb.NewMap(map[string]int32{"s": 3, "m": 0}),
+		Sizes:      duckdb.NewMap(map[int64][]float64{1: {0.5, 1.5}}),
+	}
+	empty := MapProduct{
+		Attributes: duckdb.NewMap(map[string]string{}),
+		Stock:      duckdb.NewMap(map[string]int32{}),
+		Sizes:      duckdb.NewMap(map[int64][]float64{}),
+	}
+	var null MapProduct
+	for _, product := range []*MapProduct{&full, &empty, &null} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatalf("Failed to create product: %v", err)
+		}
+	}
+
+	var got MapProduct
+	if err := db.First(&got, full.ID).Error; err != nil {
+		t.Fatalf("Failed to read product: %v", err)
+	}
+	if !reflect.DeepEqual(got.Attributes.Get(), full.Attributes.Get()) {
+		t.Errorf("Attributes: expected %v, got %v", full.Attributes.Get(), got.Attributes.Get())
+	}
+	if !reflect.DeepEqual(got.Stock.Get(), full.Stock.Get()) {
+		t.Errorf("Stock: expected %v, got %v", full.Stock.Get(), got.Stock.Get())
+	}
+	if !reflect.DeepEqual(got.Sizes.Get(), full.Sizes.Get()) {
+		t.Errorf("Sizes: expected %v, got %v", full.Sizes.Get(), got.Sizes.Get())
+	}
+
+	got = MapProduct{}
+	if err := db.First(&got, empty.ID).Error; err != nil {
+		t.Fatalf("Failed to read empty product: %v", err)
+	}
+	if got.Attributes.Get() == nil || len(got.Attributes.Get()) != 0 {
+		t.Errorf("empty map: expected an empty non-nil map, got %#v", got.Attributes.Get())
+	}
+
+	got = MapProduct{}
+	if err := db.First(&got, null.ID).Error; err != nil {
+		t.Fatalf("Failed to read NULL product: %v", err)
+	}
+	if got.Attributes.Get() != nil || got.Stock.Get() != nil {
+		t.Errorf("NULL map: expected nil, got %#v and %#v", got.Attributes.Get(), got.Stock.Get())
+	}
+	reused := duckdb.NewMap(map[string]string{"stale": "value"})
+	if err := reused.Scan(nil); err != nil || reused.Get() != nil {
+		t.Errorf("Scan(nil): expected a nil map, got %#v (err %v)", reused.Get(), err)
+	}
+	var nulls int64
+	if err := db.Model(&MapProduct{}).Where("attributes IS NULL").Count(&nulls).Error; err != nil {
+		t.Fatalf("Failed to count NULL maps: %v", err)
+	}
+	if nulls != 1 {
+		t.Errorf("expected one NULL attributes map, got %d", nulls)
+	}
+
+	if err := db.Model(&full).Update("stock", duckdb.NewMap(map[string]int32{"l": 7})).Error; err != nil {
+		t.Fatalf("Failed to update map: %v", err)
+	}
+	got = MapProduct{}
+	if err := db.First(&got, full.ID).Error; err != nil {
+		t.Fatalf("Failed to read updated product: %v", err)
+	}
+	if want := map[string]int32{"l": 7}; !reflect.DeepEqual(got.Stock.Get(), want) {
+		t.Errorf("updated Stock: expected %v, got %v", want, got.Stock.Get())
+	}
+}
+
+type StructGeo struct {
+	Lat float64
+	Lng float64
+}
+
+type StructAddress struct {
+	City     string
+	ZipCode  int
+	Tags     []string `gorm:"type:varchar[]"`
+	Location duckdb.Struct[StructGeo]
+}
+
+type StructCustomer struct {
+	ID      uint `gorm:"primaryKey"`
+	Name    string
+	Address duckdb.Struct[StructAddress]
+}
+
+func TestGenericStruct(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&StructCustomer{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := db.AutoMigrate(&StructCustomer{}); err != nil {
+		t.Fatalf("Failed to migrate again: %v", err)
+	}
+
+	var columnType string
+	if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'struct_customers' AND column_name = 'address'").Scan(&columnType).Error; err != nil {
+		t.Fatalf("Failed to read column type: %v", err)
+	}
+	expected := "STRUCT(city VARCHAR, zip_code BIGINT, tags VARCHAR[], \"location\" STRUCT(lat DOUBLE, lng DOUBLE))"
+	if columnType != expected {
+		t.Errorf("expected address column %s, got %s", expected, columnType)
+	}
+
+	address := StructAddress{
+		City:     "Amsterdam",
+		ZipCode:  1012,
+		Tags:     []string{"home", "billing"},
+		Location: duckdb.NewStruct(StructGeo{Lat: 52.37, Lng: 4.89}),
+	}
+	customer := StructCustomer{Name: "Ada", Address: duckdb.NewStruct(address)}
+	if err := db.Create(&customer).Error; err != nil {
+		t.Fatalf("Failed to create customer: %v", err)
+	}
+
+	var city string
+	if err := db.Raw("SELECT address.city FROM struct_customers WHERE id = ?", customer.ID).Scan(&city).Error; err != nil {
+		t.Fatalf("Failed to query struct field: %v", err)
+	}
+	if city != "Amsterdam" {
+		t.Errorf("expected city Amsterdam, got %s", city)
+	}
+
+	var got StructCustomer
+	if err := db.First(&got, customer.ID).Error; err != nil {
+		t.Fatalf("Failed to read customer: %v", err)
+	}
+	if !reflect.DeepEqual(got.Address.Get(), address) {
+		t.Errorf("expected address %+v, got %+v", address, got.Address.Get())
+	}
+
+	var cleared duckdb.Struct[StructAddress]
+	if err := cleared.Scan(nil); err != nil {
+		t.Fatalf("Failed to scan NULL: %v", err)
+	}
+	if !reflect.DeepEqual(cleared.Get(), StructAddress{}) {
+		t.Errorf("expected zero address after NULL, got %+v", cleared.Get())
+	}
+}
+
+type UUIDAccount struct {
+	ID       uuid.UUID `gorm:"primaryKey"`
+	ParentID *uuid.UUID
+	Name     string
+}
+
+func TestGoogleUUID(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&UUIDAccount{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	var columnTypes []string
+	if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'uuid_accounts' AND column_name IN ('id', 'parent_id') ORDER BY column_name").Scan(&columnTypes).Error; err != nil {
+		t.Fatalf("Failed to read column types: %v", err)
+	}
+	if len(columnTypes) != 2 || columnTypes[0] != "UUID" || columnTypes[1] != "UUID" {
+		t.Errorf("Expected UUID columns, got %v", columnTypes)
+	}
+
+	parent := UUIDAccount{ID: uuid.New(), Name: "parent"}
+	child := UUIDAccount{ID: uuid.New(), ParentID: &parent.ID, Name: "child"}
+	if err := db.Create(&[]UUIDAccount{parent, child}).Error; err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	var found UUIDAccount
+	if err := db.First(&found, "id = ?", child.ID).Error; err != nil {
+		t.Fatalf("Failed to query by UUID: %v", err)
+	}
+	if found.ID != child.ID || found.ParentID == nil || *found.ParentID != parent.ID {
+		t.Errorf("Expected %+v, got %+v", child, found)
+	}
+
+	var root UUIDAccount
+	if err := db.Where("parent_id IS NULL").First(&root).Error; err != nil {
+		t.Fatalf("Failed to query root: %v", err)
+	}
+	if root.ID != parent.ID || root.ParentID != nil {
+		t.Errorf("Expected %+v, got %+v", parent, root)
+	}
+
+	var text string
+	if err := db.Raw("SELECT CAST(id AS VARCHAR) FROM uuid_accounts WHERE name = ?", "child").Scan(&text).Error; err != nil {
+		t.Fatalf("Failed to read UUID text: %v", err)
+	}
+	if text != child.ID.String() {
+		t.Errorf("Expected %s, got %s", child.ID, text)
+	}
+}
+
+func TestStructFieldFilter(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&StructCustomer{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	customers := []StructCustomer{
+		{Name: "Ada", Address: duckdb.NewStruct(StructAddress{City: "Amsterdam", ZipCode: 1012, Location: duckdb.NewStruct(StructGeo{Lat: 52.37, Lng: 4.89})})},
+		{Name: "Bo", Address: duckdb.NewStruct(StructAddress{City: "Berlin", ZipCode: 10115, Location: duckdb.NewStruct(StructGeo{Lat: 52.52, Lng: 13.40})})},
+		{Name: "Cy", Address: duckdb.NewStruct(StructAddress{City: "Amsterdam", ZipCode: 1017, Location: duckdb.NewStruct(StructGeo{Lat: 52.36, Lng: 4.88})})},
+	}
+	for i := range customers {
+		if err := db.Create(&customers[i]).Error; err != nil {
+			t.Fatalf("Failed to create customer: %v", err)
+		}
+	}
+
+	var names []string
+	if err := db.Model(&StructCustomer{}).
+		Where("? = ?", duckdb.StructField("address", "city"), "Amsterdam").
+		Order("name").
+		Pluck("name", &names).Error; err != nil {
+		t.Fatalf("Failed to filter by struct member: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"Ada", "Cy"}) {
+		t.Errorf("Expected [Ada Cy], got %v", names)
+	}
+
+	var found StructCustomer
+	if err := db.Where("? > ?", duckdb.StructField("address", "location.lng"), 10).First(&found).Error; err != nil {
+		t.Fatalf("Failed to filter by nested struct member: %v", err)
+	}
+	if found.Name != "Bo" {
+		t.Errorf("Expected Bo, got %s", found.Name)
+	}
+
+	var zips []int
+	if err := db.Model(&StructCustomer{}).
+		Select("?", duckdb.StructField("address", "zip_code")).
+		Order("id").
+		Scan(&zips).Error; err != nil {
+		t.Fatalf("Failed to select struct member: %v", err)
+	}
+	if !reflect.DeepEqual(zips, []int{1012, 10115, 1017}) {
+		t.Errorf("Expected zip codes [1012 10115 1017], got %v", zips)
+	}
+}
@@ -0,0 +1,214 @@
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/marcboeker/go-duckdb/v2"
+	"gorm.io/gorm"
+)
+
+// ErrUnsupportedUDF is returned by RegisterScalarUDF when fn's signature has
+// no DuckDB equivalent.
+var ErrUnsupportedUDF = errors.New("duckdb: unsupported scalar function signature")
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// udfTypes maps the Go types a scalar function may take and return to
+// DuckDB types. int and uint are widened to BIGINT.
+var udfTypes = map[reflect.Type]duckdb.Type{
+	reflect.TypeOf(""):         duckdb.TYPE_VARCHAR,
+	reflect.TypeOf(int64(0)):   duckdb.TYPE_BIGINT,
+	reflect.TypeOf(int(0)):     duckdb.TYPE_BIGINT,
+	reflect.TypeOf(int32(0)):   duckdb.TYPE_INTEGER,
+	reflect.TypeOf(int16(0)):   duckdb.TYPE_SMALLINT,
+	reflect.TypeOf(int8(0)):    duckdb.TYPE_TINYINT,
+	reflect.TypeOf(uint64(0)):  duckdb.TYPE_UBIGINT,
+	reflect.TypeOf(uint32(0)):  duckdb.TYPE_UINTEGER,
+	reflect.TypeOf(float64(0)): duckdb.TYPE_DOUBLE,
+	reflect.TypeOf(float32(0)): duckdb.TYPE_FLOAT,
+	reflect.TypeOf(false):      duckdb.TYPE_BOOLEAN,
+	timeType:                   duckdb.TYPE_TIMESTAMP,
+	bytesType:                  duckdb.TYPE_BLOB,
+}
+
+// RegisterScalarUDF registers the Go function fn as the DuckDB scalar
+// function name, callable from any query on db:
+//
+//	duckdb.RegisterScalarUDF(db, "slugify", func(s string) string { ... })
+//	db.Model(&Post{}).Select("slugify(title)").Scan(&slugs)
+//
+// fn takes parameters of type string, int64, int, int32, int16, int8,
+// uint64, uint32, float64, float32, bool, time.Time or []byte and returns one
+// such value, optionally followed by an error that fails the query. A NULL
+// argument yields NULL without calling fn. Other signatures, including
+// variadic functions, return ErrUnsupportedUDF.
+//
+// Functions live in the database's catalog, so they are visible to every
+// pooled connection; registering a name twice fails.
+func RegisterScalarUDF(db *gorm.DB, name string, fn interface{}) error {
+	udf, err := newScalarUDF(fn)
+	if err != nil {
+		return err
+	}
+
+	conn, release, err := udfConn(db)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := duckdb.RegisterScalarUDF(conn, name, udf); err != nil {
+		return fmt.Errorf("failed to register scalar function %s: %w", name, err)
+	}
+	return nil
+}
+
+// udfConn returns a connection go-duckdb can register functions on. The
+// driver's own connections wrap go-duckdb's, which RegisterScalarUDF does
+// not accept, so a connection is opened directly on the underlying
+// connector instead.
+func udfConn(db *gorm.DB) (*sql.Conn, func(), error) {
+	ctx := statementContext(db)
+
+	connector, err := GetConnector(db)
+	if errors.Is(err, ErrNoConnector) {
+		// Opened on an existing go-duckdb *sql.DB; its connections are native
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get underlying database: %w", err)
+		}
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to acquire connection: %w", err)
+		}
+		return conn, func() { _ = conn.Close() }, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Hiding the connector's Close keeps closing this pool from closing the
+	// database it shares with db.
+	pool := sql.OpenDB(struct{ driver.Connector }{connector})
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		_ = pool.Close()
+		return nil, nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	return conn, func() {
+		_ = conn.Close()
+		_ = pool.Close()
+	}, nil
+}
+
+// scalarUDF adapts a Go function to go-duckdb's ScalarFunc
+type scalarUDF struct {
+	fn     reflect.Value
+	config duckdb.ScalarFuncConfig
+}
+
+// newScalarUDF validates fn's signature and builds its DuckDB types
+func newScalarUDF(fn interface{}) (*scalarUDF, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, fmt.Errorf("%w: %T is not a function", ErrUnsupportedUDF, fn)
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() {
+		return nil, fmt.Errorf("%w: %s is variadic", ErrUnsupportedUDF, ft)
+	}
+	if ft.NumOut() == 0 || ft.NumOut() > 2 || (ft.NumOut() == 2 && ft.Out(1) != errorType) {
+		return nil, fmt.Errorf("%w: %s must return a value, optionally followed by an error", ErrUnsupportedUDF, ft)
+	}
+
+	inputs := make([]duckdb.TypeInfo, ft.NumIn())
+	for i := range inputs {
+		info, err := udfTypeInfo(ft.In(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: parameter %d of %s: %v", ErrUnsupportedUDF, i+1, ft, err)
+		}
+		inputs[i] = info
+	}
+	result, err := udfTypeInfo(ft.Out(0))
+	if err != nil {
+		return nil, fmt.Errorf("%w: result of %s: %v", ErrUnsupportedUDF, ft, err)
+	}
+
+	return &scalarUDF{
+		fn: fv,
+		config: duckdb.ScalarFuncConfig{
+			InputTypeInfos: inputs,
+			ResultTypeInfo: result,
+		},
+	}, nil
+}
+
+// udfTypeInfo returns the DuckDB type info for a parameter or result type
+func udfTypeInfo(t reflect.Type) (duckdb.TypeInfo, error) {
+	typ, ok := udfTypes[t]
+	if !ok {
+		return nil, fmt.Errorf("type %s has no DuckDB equivalent", t)
+	}
+	return duckdb.NewTypeInfo(typ)
+}
+
+// Config implements duckdb.ScalarFunc
+func (u *scalarUDF) Config() duckdb.ScalarFuncConfig {
+	return u.config
+}
+
+// Executor implements duckdb.ScalarFunc
+func (u *scalarUDF) Executor() duckdb.ScalarFuncExecutor {
+	return duckdb.ScalarFuncExecutor{RowExecutor: u.call}
+}
+
+// call converts one row of DuckDB values to fn's parameter types, calls fn
+// and converts its result back.
+func (u *scalarUDF) call(values []driver.Value) (any, error) {
+	ft := u.fn.Type()
+	args := make([]reflect.Value, len(values))
+	for i, value := range values {
+		arg, err := udfArgument(value, ft.In(i))
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+
+	out := u.fn.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+
+	result := out[0]
+	switch result.Kind() {
+	case reflect.Int:
+		return result.Int(), nil
+	default:
+		return result.Interface(), nil
+	}
+}
+
+// udfArgument converts a value read by go-duckdb to the parameter type t
+func udfArgument(value driver.Value, t reflect.Type) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if v.Type() == t {
+		return v, nil
+	}
+	if v.Type().ConvertibleTo(t) && v.Kind() != reflect.String {
+		return v.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot pass %T as %s", value, t)
+}
@@ -0,0 +1,86 @@
+package duckdb_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+type PricedItem struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Cents int64
+}
+
+func TestRegisterScalarUDF(t *testing.T) {
+	db, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&PricedItem{}))
+	require.NoError(t, db.Create(&[]PricedItem{
+		{Name: "widget", Cents: 1000},
+		{Name: "gadget", Cents: 250},
+	}).Error)
+
+	require.NoError(t, duckdb.RegisterScalarUDF(db, "shout", func(s string) string {
+		return strings.ToUpper(s) + "!"
+	}))
+	require.NoError(t, duckdb.RegisterScalarUDF(db, "with_tax", func(cents int64) int64 {
+		return cents * 120 / 100
+	}))
+	require.NoError(t, duckdb.RegisterScalarUDF(db, "ratio", func(a, b float64) (float64, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	}))
+
+	var names []string
+	require.NoError(t, db.Model(&PricedItem{}).Order("id").Select("shout(name)").Scan(&names).Error)
+	assert.Equal(t, []string{"WIDGET!", "GADGET!"}, names)
+
+	var taxed []int64
+	require.NoError(t, db.Model(&PricedItem{}).Where("with_tax(cents) > ?", 1000).Select("with_tax(cents)").Scan(&taxed).Error)
+	assert.Equal(t, []int64{1200}, taxed)
+
+	var ratio float64
+	require.NoError(t, db.Raw("SELECT ratio(3, 4)").Scan(&ratio).Error)
+	assert.InDelta(t, 0.75, ratio, 1e-9)
+	assert.Error(t, db.Raw("SELECT ratio(1, 0)").Scan(&ratio).Error)
+
+	var shouted *string
+	require.NoError(t, db.Raw("SELECT shout(NULL)").Scan(&shouted).Error)
+	assert.Nil(t, shouted)
+
+	t.Run("InTransaction", func(t *testing.T) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var name string
+			if err := tx.Raw("SELECT shout(?)", "tx").Scan(&name).Error; err != nil {
+				return err
+			}
+			assert.Equal(t, "TX!", name)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("UnsupportedSignatures", func(t *testing.T) {
+		for name, fn := range map[string]interface{}{
+			"not_a_func":   "shout",
+			"no_result":    func(string) {},
+			"map_param":    func(map[string]int) string { return "" },
+			"bad_second":   func(string) (string, int) { return "", 0 },
+			"variadic":     func(...string) string { return "" },
+			"struct_value": func(string) PricedItem { return PricedItem{} },
+		} {
+			err := duckdb.RegisterScalarUDF(db, name, fn)
+			assert.ErrorIs(t, err, duckdb.ErrUnsupportedUDF, name)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Warmup primes the connection pool before serving traffic: it loads the
+// extensions configured through OpenWithExtensions, then opens n connections
+// and runs a query on each so none of that cost lands on the first requests.
+// Connections are returned to the pool afterwards, so set SetMaxIdleConns to
+// at least n or database/sql closes the surplus again.
+func Warmup(db *gorm.DB, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("warmup connection count must be positive, got %d", n)
+	}
+
+	if _, ok := db.Dialector.(*extensionAwareDialector); ok {
+		if err := InitializeExtensions(db); err != nil {
+			return fmt.Errorf("failed to preload extensions during warmup: %w", err)
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying database: %w", err)
+	}
+
+	// Hold every connection until all are open so the pool cannot hand the
+	// same one out twice
+	ctx := statementContext(db)
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to open connection %d of %d: %w", i+1, n, err)
+		}
+		conns = append(conns, conn)
+
+		if _, err := conn.ExecContext(ctx, "SELECT 1"); err != nil {
+			return fmt.Errorf("failed to initialize connection %d of %d: %w", i+1, n, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package duckdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	duckdb "github.com/greysquirr3l/gorm-duckdb-driver"
+)
+
+func TestWarmup(t *testing.T) {
+	dialector := duckdb.OpenWithExtensions(":memory:", &duckdb.ExtensionConfig{
+		PreloadExtensions: []string{duckdb.ExtensionJSON},
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxIdleConns(4)
+
+	require.NoError(t, duckdb.Warmup(db, 4))
+
+	stats := sqlDB.Stats()
+	assert.Equal(t, 4, stats.OpenConnections)
+	assert.Equal(t, 4, stats.Idle)
+	assert.True(t, duckdb.MustGetExtensionManager(db).IsExtensionLoaded(duckdb.ExtensionJSON))
+
+	// Serving a query reuses a warm connection instead of opening one
+	var one int
+	require.NoError(t, db.Raw("SELECT 1").Scan(&one).Error)
+	assert.Equal(t, 4, sqlDB.Stats().OpenConnections)
+
+	t.Run("PlainDialector", func(t *testing.T) {
+		plain, err := gorm.Open(duckdb.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		require.NoError(t, duckdb.Warmup(plain, 2))
+
+		plainDB, err := plain.DB()
+		require.NoError(t, err)
+		assert.Equal(t, 2, plainDB.Stats().Idle)
+	})
+
+	t.Run("InvalidCount", func(t *testing.T) {
+		assert.Error(t, duckdb.Warmup(db, 0))
+	})
+}